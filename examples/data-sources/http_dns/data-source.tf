@@ -0,0 +1,12 @@
+# The following example shows how to look up the mail servers of a domain
+# using the JSON API of a DoH resolver.
+data "http_dns" "example" {
+  name     = "example.com"
+  type     = "MX"
+  endpoint = "https://dns.google/resolve"
+  format   = "json"
+}
+
+output "mail_servers" {
+  value = data.http_dns.example.values
+}
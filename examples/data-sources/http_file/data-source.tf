@@ -0,0 +1,13 @@
+# The following example shows how to download a machine image to a local
+# file and verify its checksum, without storing the image in the state.
+data "http_file" "example" {
+  url         = "https://releases.example.com/images/base-1.2.3.qcow2"
+  destination = "${path.module}/images/base.qcow2"
+
+  lifecycle {
+    postcondition {
+      condition     = self.sha256 == var.base_image_sha256
+      error_message = "Checksum mismatch"
+    }
+  }
+}
@@ -0,0 +1,17 @@
+# The following example shows how to find the latest 1.x release
+# listed by a JSON API and construct its download URL.
+data "http_latest_version" "example" {
+  url = "https://api.releases.hashicorp.com/v1/releases/terraform"
+
+  version_jsonpath      = "$[*].version"
+  version_constraint    = "~> 1.0"
+  download_url_template = "https://releases.hashicorp.com/terraform/{version}/terraform_{version}_linux_amd64.zip"
+}
+
+# The following example shows how to find the latest release
+# listed on an HTML page.
+data "http_latest_version" "example_html" {
+  url = "https://releases.hashicorp.com/terraform/"
+
+  version_regex = "terraform_(?P<version>[0-9]+\\.[0-9]+\\.[0-9]+)<"
+}
@@ -0,0 +1,25 @@
+# The following example shows how to check the health endpoints of several
+# services with a single data source.
+data "http_multi" "health" {
+  urls = {
+    for name, host in var.service_hosts : name => "https://${host}/healthz"
+  }
+
+  request_headers = {
+    Accept = "application/json"
+  }
+
+  max_concurrency = 16
+
+  retry {
+    attempts     = 2
+    min_delay_ms = 500
+  }
+}
+
+output "unhealthy_services" {
+  value = [
+    for name, response in data.http_multi.health.responses : name
+    if response.status_code != 200
+  ]
+}
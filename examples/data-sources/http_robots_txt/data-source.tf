@@ -0,0 +1,15 @@
+# The following example shows how to ensure that a site does not
+# disallow crawling of its root path for all user agents.
+data "http_robots_txt" "example" {
+  url = "https://www.example.com"
+
+  lifecycle {
+    postcondition {
+      condition = alltrue([
+        for group in self.groups : !contains(group.disallow, "/")
+        if contains(group.user_agents, "*")
+      ])
+      error_message = "Site disallows crawling for all user agents"
+    }
+  }
+}
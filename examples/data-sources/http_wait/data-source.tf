@@ -0,0 +1,10 @@
+# The following example shows how to wait for a service to report
+# that it is healthy before using it.
+data "http_wait" "example" {
+  url         = "https://api.example.com/health"
+  interval_ms = 10000
+  timeout_ms  = 600000
+
+  jsonpath        = "$.status"
+  jsonpath_equals = "healthy"
+}
@@ -0,0 +1,16 @@
+# The following example shows how to request an access token with the
+# client credentials grant and use it to authenticate requests, without
+# storing the token in the plan or state.
+ephemeral "http_oauth2_token" "example" {
+  token_url     = "https://auth.example.com/oauth/token"
+  client_id     = var.client_id
+  client_secret = var.client_secret
+  scopes        = ["inventory:read"]
+}
+
+provider "http" {
+  host {
+    name         = "api.example.com"
+    bearer_token = ephemeral.http_oauth2_token.example.access_token
+  }
+}
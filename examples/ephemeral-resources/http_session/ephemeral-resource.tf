@@ -0,0 +1,24 @@
+# The following example shows how to log in to an appliance and use the
+# session cookie and CSRF token for subsequent requests. The session is
+# logged out once it is no longer needed.
+ephemeral "http_session" "example" {
+  login_url = "https://appliance.example.com/api/login"
+
+  json_body = jsonencode({
+    username = var.username
+    password = var.password
+  })
+
+  capture_headers = ["X-CSRF-Token"]
+  logout_url      = "https://appliance.example.com/api/logout"
+}
+
+provider "http" {
+  host {
+    name = "appliance.example.com"
+    request_headers = {
+      Cookie       = ephemeral.http_session.example.cookie_header
+      X-CSRF-Token = ephemeral.http_session.example.headers["X-CSRF-Token"]
+    }
+  }
+}
@@ -0,0 +1,11 @@
+data "http" "example" {
+  url = "https://www.example.com"
+}
+
+# The following example shows how to retrieve the individual directives
+# of a Cache-Control response header, such as ["no-cache", "max-age=0"].
+output "cache_control" {
+  value = provider::http::parse_header_values(
+    lookup(data.http.example.response_headers, "Cache-Control", "")
+  )
+}
@@ -0,0 +1,7 @@
+# The following example shows how to decode the last path segment of a URL,
+# such as "2024 Q1.csv".
+output "file_name" {
+  value = provider::http::urldecode(
+    reverse(split("/", "https://storage.example.com/reports/2024%20Q1.csv"))[0]
+  )
+}
@@ -0,0 +1,12 @@
+# The following example shows how to parse a form-encoded response body,
+# such as "access_token=abc&scope=read+write", into a map.
+data "http" "example" {
+  url = "https://www.example.com/token"
+}
+
+output "params" {
+  value = {
+    for pair in split("&", data.http.example.response_body) :
+    provider::http::urldecode_query(split("=", pair)[0]) => provider::http::urldecode_query(try(split("=", pair)[1], ""))
+  }
+}
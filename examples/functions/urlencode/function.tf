@@ -0,0 +1,5 @@
+# The following example shows how to request a file whose name contains
+# reserved characters, such as "reports/2024 Q1.csv".
+data "http" "example" {
+  url = "https://storage.example.com/buckets/example/objects/${provider::http::urlencode("reports/2024 Q1.csv")}"
+}
@@ -0,0 +1,15 @@
+# The following example shows how to build a query string from a map of
+# parameters, such as "page=2&q=terraform+provider".
+locals {
+  params = {
+    q    = "terraform provider"
+    page = "2"
+  }
+}
+
+data "http" "example" {
+  url = "https://www.example.com/search?${join("&", [
+    for name, value in local.params :
+    "${provider::http::urlencode_query(name)}=${provider::http::urlencode_query(value)}"
+  ])}"
+}
@@ -0,0 +1,4 @@
+provider "http" {
+  # Enforce migration away from deprecated attributes.
+  strict = true
+}
@@ -0,0 +1,386 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/net/http/httpproxy"
+)
+
+// newTransport returns a clone of the default transport, configured with the
+// given `ca_cert_pem` and `insecure` settings and the provider configuration.
+// Data sources with the same settings share a transport, so that connections
+// are reused.
+func newTransport(p *providerData, caCertificate types.String, insecure types.Bool) (*http.Transport, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if p != nil {
+		if insecure.IsNull() && p.insecure != nil {
+			insecure = types.BoolValue(*p.insecure)
+		}
+
+		if caCertificate.IsNull() && p.caCertPEM != "" {
+			caCertificate = types.StringValue(p.caCertPEM)
+		}
+	}
+
+	key := transportKey{
+		caCertPEM: caCertificate.ValueString(),
+		insecure:  insecure.ValueBool(),
+	}
+
+	if p != nil {
+		key.connectTimeout = p.connectTimeout
+		key.tlsHandshakeTimeout = p.tlsHandshakeTimeout
+		key.responseHeaderTimeout = p.responseHeaderTimeout
+		key.cipherSuites = fmt.Sprint(p.cipherSuites)
+		key.renegotiation = p.renegotiation
+		key.pinnedPublicKeys = strings.Join(p.pinnedPublicKeys, ",")
+		key.crls = crlsKey(p.crls)
+		key.requireOCSPStaple = p.requireOCSPStaple
+		key.ocspCheck = p.ocspCheck
+
+		if tr, ok := p.transports.get(key); ok {
+			return tr, diags
+		}
+	}
+
+	tr, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		diags.AddError(
+			"Error configuring http transport",
+			"Error http: Can't configure http transport.",
+		)
+		return nil, diags
+	}
+
+	// Prevent issues with multiple data source configurations modifying the shared transport.
+	clonedTr := tr.Clone()
+
+	// Prevent issues with tests caching the proxy configuration.
+	clonedTr.Proxy = func(req *http.Request) (*url.URL, error) {
+		proxyConfig := httpproxy.FromEnvironment()
+
+		if p != nil && p.ignoreProxyEnv {
+			proxyConfig = &httpproxy.Config{}
+		}
+
+		if p != nil && p.httpProxy != "" {
+			proxyConfig.HTTPProxy = p.httpProxy
+		}
+
+		if p != nil && p.httpsProxy != "" {
+			proxyConfig.HTTPSProxy = p.httpsProxy
+		}
+
+		if p != nil && len(p.noProxy) > 0 {
+			proxyConfig.NoProxy = strings.Join(append([]string{proxyConfig.NoProxy}, p.noProxy...), ",")
+		}
+
+		return proxyConfig.ProxyFunc()(req.URL)
+	}
+
+	if clonedTr.TLSClientConfig == nil {
+		clonedTr.TLSClientConfig = &tls.Config{}
+	}
+
+	if p != nil {
+		setTransportTimeouts(clonedTr, p.connectTimeout, p.tlsHandshakeTimeout, p.responseHeaderTimeout)
+		p.connectionPool.apply(clonedTr)
+	}
+
+	if !insecure.IsNull() {
+		clonedTr.TLSClientConfig.InsecureSkipVerify = insecure.ValueBool()
+	}
+
+	if p != nil && p.cipherSuites != nil {
+		clonedTr.TLSClientConfig.CipherSuites = p.cipherSuites
+	}
+
+	if p != nil {
+		clonedTr.TLSClientConfig.Renegotiation = p.renegotiation
+	}
+
+	if p != nil {
+		var verifiers []func(tls.ConnectionState) error
+
+		// The pinned public keys are verified instead of the certificate
+		// chain, which allows connecting to servers with self-signed
+		// certificates.
+		if len(p.pinnedPublicKeys) > 0 {
+			clonedTr.TLSClientConfig.InsecureSkipVerify = true
+			verifiers = append(verifiers, verifyPinnedPublicKeys(p.pinnedPublicKeys))
+		}
+
+		if len(p.crls) > 0 {
+			verifiers = append(verifiers, verifyCRLs(p.crls))
+		}
+
+		if p.requireOCSPStaple || p.ocspCheck {
+			verifiers = append(verifiers, verifyOCSP(p, p.requireOCSPStaple, p.ocspCheck))
+		}
+
+		clonedTr.TLSClientConfig.VerifyConnection = verifyConnection(verifiers...)
+	}
+
+	// Use `ca_cert_pem` cert pool
+	if !caCertificate.IsNull() {
+		caCertPool := x509.NewCertPool()
+		if ok := caCertPool.AppendCertsFromPEM([]byte(caCertificate.ValueString())); !ok {
+			diags.AddError(
+				"Error configuring TLS client",
+				"Error tls: Can't add the CA certificate to certificate pool. Only PEM encoded certificates are supported.",
+			)
+			return nil, diags
+		}
+
+		clonedTr.TLSClientConfig.RootCAs = caCertPool
+	}
+
+	if p != nil {
+		clonedTr = p.transports.put(key, clonedTr)
+	}
+
+	return clonedTr, diags
+}
+
+// transportKey identifies the settings of a transport which differ between
+// data sources.
+type transportKey struct {
+	caCertPEM             string
+	insecure              bool
+	connectTimeout        time.Duration
+	tlsHandshakeTimeout   time.Duration
+	responseHeaderTimeout time.Duration
+	cipherSuites          string
+	renegotiation         tls.RenegotiationSupport
+	pinnedPublicKeys      string
+	crls                  string
+	requireOCSPStaple     bool
+	ocspCheck             bool
+}
+
+// transportCache holds the transports shared by data sources. A nil cache
+// does not hold any transports.
+type transportCache struct {
+	mu         sync.Mutex
+	transports map[transportKey]*http.Transport
+}
+
+func newTransportCache() *transportCache {
+	return &transportCache{
+		transports: make(map[transportKey]*http.Transport),
+	}
+}
+
+// get returns the transport with the given settings, if any.
+func (c *transportCache) get(key transportKey) (*http.Transport, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	tr, ok := c.transports[key]
+
+	return tr, ok
+}
+
+// put stores the transport unless a transport with the same settings has
+// been stored concurrently, and returns the stored transport.
+func (c *transportCache) put(key transportKey, tr *http.Transport) *http.Transport {
+	if c == nil {
+		return tr
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if existing, ok := c.transports[key]; ok {
+		return existing
+	}
+
+	c.transports[key] = tr
+
+	return tr
+}
+
+// connectionPool is the connection pool configuration of transports. A nil
+// field leaves the corresponding setting of the default transport unchanged.
+type connectionPool struct {
+	maxIdleConns        *int
+	maxIdleConnsPerHost *int
+	maxConnsPerHost     *int
+	idleConnTimeout     *time.Duration
+	disableKeepAlives   bool
+}
+
+// apply sets the connection pool configuration of the transport.
+func (c *connectionPool) apply(tr *http.Transport) {
+	if c == nil {
+		return
+	}
+
+	if c.maxIdleConns != nil {
+		tr.MaxIdleConns = *c.maxIdleConns
+	}
+
+	if c.maxIdleConnsPerHost != nil {
+		tr.MaxIdleConnsPerHost = *c.maxIdleConnsPerHost
+	}
+
+	if c.maxConnsPerHost != nil {
+		tr.MaxConnsPerHost = *c.maxConnsPerHost
+	}
+
+	if c.idleConnTimeout != nil {
+		tr.IdleConnTimeout = *c.idleConnTimeout
+	}
+
+	tr.DisableKeepAlives = c.disableKeepAlives
+}
+
+// roundTripper returns the transport used for requests, limited according to
+// the provider configuration.
+func roundTripper(p *providerData, tr *http.Transport) http.RoundTripper {
+	var rt http.RoundTripper = tr
+
+	if p == nil {
+		return rt
+	}
+
+	// The duration of requests excludes the time waiting for the limits.
+	if p.metrics != nil {
+		rt = &metricsTransport{
+			base:    rt,
+			metrics: p.metrics,
+		}
+	}
+
+	// The rate limit applies before waiting for a free request slot, so that
+	// a request does not hold a slot while it is throttled.
+	if p.rateLimiter != nil {
+		rt = &rateLimitedTransport{
+			base:    rt,
+			limiter: p.rateLimiter,
+		}
+	}
+
+	if p.requestSlots != nil {
+		rt = &concurrencyLimitedTransport{
+			base:  rt,
+			slots: p.requestSlots,
+		}
+	}
+
+	// Each attempt is traced, including the time waiting for the limits.
+	if p.tracerProvider != nil {
+		rt = &tracingTransport{
+			base:   rt,
+			tracer: p.tracer(),
+		}
+	}
+
+	return rt
+}
+
+// setTransportTimeouts sets the timeouts of the individual phases of a
+// request. A zero duration leaves the corresponding timeout unchanged.
+func setTransportTimeouts(tr *http.Transport, connect, tlsHandshake, responseHeader time.Duration) {
+	if connect > 0 {
+		dialer := &net.Dialer{
+			Timeout:   connect,
+			KeepAlive: 30 * time.Second,
+		}
+
+		tr.DialContext = dialer.DialContext
+	}
+
+	if tlsHandshake > 0 {
+		tr.TLSHandshakeTimeout = tlsHandshake
+	}
+
+	if responseHeader > 0 {
+		tr.ResponseHeaderTimeout = responseHeader
+	}
+}
+
+// newRequest returns a request with the given request headers.
+func newRequest(ctx context.Context, method, requestURL string, body io.Reader, requestHeaders types.Map) (*http.Request, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	request, err := http.NewRequestWithContext(ctx, method, requestURL, body)
+	if err != nil {
+		diags.AddError(
+			"Error creating request",
+			fmt.Sprintf("Error creating request: %s", err),
+		)
+		return nil, diags
+	}
+
+	var headers map[string]string
+	diags.Append(requestHeaders.ElementsAs(ctx, &headers, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	for name, value := range headers {
+		request.Header.Set(name, value)
+		if strings.ToLower(name) == "host" {
+			request.Host = value
+		}
+	}
+
+	return request, diags
+}
+
+// doGetRequest makes a single GET request, without retries, using the given
+// transport and returns the response along with its body.
+func doGetRequest(ctx context.Context, tr http.RoundTripper, requestURL string, requestHeaders types.Map, timeout time.Duration) (*http.Response, []byte, diag.Diagnostics) {
+	client := &http.Client{
+		Transport: tr,
+		Timeout:   timeout,
+	}
+
+	request, diags := newRequest(ctx, http.MethodGet, requestURL, nil, requestHeaders)
+	if diags.HasError() {
+		return nil, nil, diags
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		diags.AddError(
+			"Error making request",
+			fmt.Sprintf("Error making request: %s\n\nError code: %s", err, requestErrorCode(err)),
+		)
+		return nil, nil, diags
+	}
+
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		diags.AddError(
+			"Error reading response body",
+			fmt.Sprintf("Error reading response body: %s", err),
+		)
+		return nil, nil, diags
+	}
+
+	return response, body, diags
+}
@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"io"
+	"net/http"
+	"sync"
+)
+
+// concurrencyLimitedTransport holds one of the slots of the semaphore while a
+// request is in flight, until its response body is read, so that the
+// number of concurrent requests of all data sources is bounded.
+type concurrencyLimitedTransport struct {
+	base  http.RoundTripper
+	slots chan struct{}
+}
+
+func (t *concurrencyLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	select {
+	case t.slots <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+
+	release := sync.OnceFunc(func() { <-t.slots })
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	resp.Body = &releasingBody{
+		ReadCloser: resp.Body,
+		release:    release,
+	}
+
+	return resp, nil
+}
+
+// releasingBody releases a request slot when the response body has been read
+// or is closed, whichever happens first, so that a data source which makes
+// further requests, such as for pages, before closing the body does not
+// deadlock.
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releasingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err != nil {
+		b.release()
+	}
+
+	return n, err
+}
+
+func (b *releasingBody) Close() error {
+	defer b.release()
+
+	return b.ReadCloser.Close()
+}
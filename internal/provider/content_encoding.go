@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+)
+
+// contentDecodingReader returns a reader which decodes the response body
+// according to its Content-Encoding header. Encodings are removed in the
+// reverse order to which they were applied.
+func contentDecodingReader(header http.Header, body io.Reader) (io.Reader, error) {
+	var encodings []string
+
+	for _, value := range header.Values("Content-Encoding") {
+		for _, encoding := range strings.Split(value, ",") {
+			encoding = strings.ToLower(strings.TrimSpace(encoding))
+
+			if encoding != "" && encoding != "identity" {
+				encodings = append(encodings, encoding)
+			}
+		}
+	}
+
+	reader := body
+
+	for i := len(encodings) - 1; i >= 0; i-- {
+		switch encodings[i] {
+		case "gzip", "x-gzip":
+			gzipReader, err := gzip.NewReader(reader)
+			if err != nil {
+				return nil, err
+			}
+
+			reader = gzipReader
+		case "deflate":
+			zlibReader, err := zlib.NewReader(reader)
+			if err != nil {
+				return nil, err
+			}
+
+			reader = zlibReader
+		case "br":
+			reader = brotli.NewReader(reader)
+		default:
+			return nil, fmt.Errorf("unsupported content encoding %q", encodings[i])
+		}
+	}
+
+	return reader, nil
+}
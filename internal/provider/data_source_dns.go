@@ -0,0 +1,590 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+var (
+	_ datasource.DataSource              = (*dnsDataSource)(nil)
+	_ datasource.DataSourceWithConfigure = (*dnsDataSource)(nil)
+)
+
+// DNS-over-HTTPS formats, configured via `format`.
+const (
+	dnsFormatWire = "wire"
+	dnsFormatJSON = "json"
+)
+
+// dnsDefaultEndpoint is the default `endpoint` of the `http_dns` data source.
+const dnsDefaultEndpoint = "https://cloudflare-dns.com/dns-query"
+
+// dnsTypes are the supported record types, by name.
+var dnsTypes = map[string]dnsmessage.Type{
+	"A":     dnsmessage.TypeA,
+	"AAAA":  dnsmessage.TypeAAAA,
+	"CAA":   dnsmessage.Type(257),
+	"CNAME": dnsmessage.TypeCNAME,
+	"MX":    dnsmessage.TypeMX,
+	"NS":    dnsmessage.TypeNS,
+	"PTR":   dnsmessage.TypePTR,
+	"SOA":   dnsmessage.TypeSOA,
+	"SRV":   dnsmessage.TypeSRV,
+	"TXT":   dnsmessage.TypeTXT,
+}
+
+func NewDNSDataSource() datasource.DataSource {
+	return &dnsDataSource{}
+}
+
+type dnsDataSource struct {
+	providerData *providerData
+}
+
+func (d *dnsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	data, diags := configureProviderData(req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+	d.providerData = data
+}
+
+func (d *dnsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns"
+}
+
+func (d *dnsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	typeNames := make([]string, 0, len(dnsTypes))
+	for name := range dnsTypes {
+		typeNames = append(typeNames, name)
+	}
+
+	slices.Sort(typeNames)
+
+	resp.Schema = schema.Schema{
+		Description: `
+The ` + "`http_dns`" + ` data source resolves a name using a DNS-over-HTTPS (DoH) resolver, either with DNS
+wire format messages according to [RFC 8484](https://datatracker.ietf.org/doc/html/rfc8484) or with the JSON
+API offered by resolvers such as Cloudflare and Google.
+
+The request is made like any other request of the provider, so the provider proxy, TLS and host-specific
+configuration applies. A name which does not exist is not an error: its ` + "`rcode`" + ` is ` + "`NXDOMAIN`" + `
+and it has no records.
+`,
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The name and type of the query, e.g. `example.com/A`.",
+				Computed:    true,
+			},
+
+			"name": schema.StringAttribute{
+				Description: "The name to resolve, such as `example.com`.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"type": schema.StringAttribute{
+				Description: fmt.Sprintf("The record type to query, one of `%s`. Defaults to `A`.",
+					strings.Join(typeNames, "`, `")),
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(typeNames...),
+				},
+			},
+
+			"endpoint": schema.StringAttribute{
+				Description: fmt.Sprintf("The URL of the DoH resolver. Defaults to `%s`.", dnsDefaultEndpoint),
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^https?://`), "must be an http or https URL"),
+				},
+			},
+
+			"format": schema.StringAttribute{
+				Description: "The format of the messages, either `wire` for the `application/dns-message` format of " +
+					"RFC 8484, or `json` for the `application/dns-json` format. Defaults to `wire`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(dnsFormatWire, dnsFormatJSON),
+				},
+			},
+
+			"request_headers": schema.MapAttribute{
+				Description: "A map of request header field names and values.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+
+			"request_timeout_ms": schema.Int64Attribute{
+				Description: "The request timeout in milliseconds.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"ca_cert_pem": schema.StringAttribute{
+				Description: "Certificate data of the Certificate Authority (CA) " +
+					"in [PEM (RFC 1421)](https://datatracker.ietf.org/doc/html/rfc1421) format.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("insecure")),
+				},
+			},
+
+			"insecure": schema.BoolAttribute{
+				Description: "Disables verification of the server's certificate chain and hostname. Defaults to `false`",
+				Optional:    true,
+			},
+
+			"rcode": schema.StringAttribute{
+				Description: "The response code of the resolver, either `NOERROR` or `NXDOMAIN`.",
+				Computed:    true,
+			},
+
+			"records": schema.ListAttribute{
+				Description: "The records of the answer, in the order returned by the resolver, including any " +
+					"`CNAME` records of aliases. Each record has the owner `name`, the `type`, the `ttl` in seconds " +
+					"and the `data` in presentation format, e.g. `10 mail.example.com.` for an `MX` record.",
+				ElementType: types.ObjectType{AttrTypes: dnsRecordAttrTypes},
+				Computed:    true,
+			},
+
+			"values": schema.ListAttribute{
+				Description: "The `data` of the records of the queried `type`.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *dnsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	// Export the spans of the requests, if tracing is configured.
+	defer d.providerData.flushSpans(ctx)
+
+	var model dnsModelV0
+	diags := req.Config.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := model.Name.ValueString()
+
+	typeName := "A"
+	if !model.Type.IsNull() {
+		typeName = model.Type.ValueString()
+	}
+
+	endpoint := dnsDefaultEndpoint
+	if !model.Endpoint.IsNull() {
+		endpoint = model.Endpoint.ValueString()
+	}
+
+	format := dnsFormatWire
+	if !model.Format.IsNull() {
+		format = model.Format.ValueString()
+	}
+
+	requestURL, accept, err := dnsQueryURL(endpoint, format, name, dnsTypes[typeName])
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Creating DNS Query",
+			fmt.Sprintf("The query for the %s records of %q could not be created: %s", typeName, name, err),
+		)
+		return
+	}
+
+	providerData := d.providerData.forURL(requestURL)
+
+	requestHeaders, diags := requestHeadersWithDefaults(providerData, model.RequestHeaders)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tr, diags := newTransport(providerData, model.CaCertificate, model.Insecure)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := &http.Client{
+		Transport: roundTripper(providerData, tr),
+		Timeout:   requestTimeout(providerData, model.RequestTimeout),
+	}
+
+	request, diags := newRequest(ctx, http.MethodGet, requestURL, nil, requestHeaders)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	request.Header.Set("Accept", accept)
+
+	response, err := client.Do(request)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error making request",
+			fmt.Sprintf("Error making request: %s\n\nError code: %s", err, requestErrorCode(err)),
+		)
+		return
+	}
+
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading response body",
+			fmt.Sprintf("Error reading response body: %s", err),
+		)
+		return
+	}
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		resp.Diagnostics.AddError(
+			"Unexpected Response Status Code",
+			fmt.Sprintf("The DoH resolver %s responded with the status %q.", request.URL.Redacted(), response.Status),
+		)
+		return
+	}
+
+	var answer dnsAnswer
+
+	if format == dnsFormatJSON {
+		answer, err = parseDNSJSONMessage(body)
+	} else {
+		answer, err = parseDNSWireMessage(body)
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Parsing DNS Response",
+			fmt.Sprintf("The response of the DoH resolver %s could not be parsed: %s", request.URL.Redacted(), err),
+		)
+		return
+	}
+
+	if answer.rcode != dnsmessage.RCodeSuccess && answer.rcode != dnsmessage.RCodeNameError {
+		resp.Diagnostics.AddError(
+			"DNS Query Failed",
+			fmt.Sprintf("The DoH resolver %s could not resolve the %s records of %q: %s",
+				request.URL.Redacted(), typeName, name, dnsRCodeName(answer.rcode)),
+		)
+		return
+	}
+
+	values := []string{}
+	for _, record := range answer.records {
+		if record.Type.ValueString() == typeName {
+			values = append(values, record.Data.ValueString())
+		}
+	}
+
+	model.ID = types.StringValue(name + "/" + typeName)
+	model.RCode = types.StringValue(dnsRCodeName(answer.rcode))
+
+	model.Records, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: dnsRecordAttrTypes}, answer.records)
+	resp.Diagnostics.Append(diags...)
+
+	model.Values, diags = types.ListValueFrom(ctx, types.StringType, values)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+// dnsQueryURL returns the URL of a GET request for the query in the given
+// format, along with the media type of the response.
+func dnsQueryURL(endpoint, format, name string, qtype dnsmessage.Type) (string, string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", "", err
+	}
+
+	query := u.Query()
+
+	if format == dnsFormatJSON {
+		query.Set("name", name)
+		query.Set("type", strconv.Itoa(int(qtype)))
+		u.RawQuery = query.Encode()
+
+		return u.String(), "application/dns-json", nil
+	}
+
+	questionName, err := dnsmessage.NewName(dnsFQDN(name))
+	if err != nil {
+		return "", "", err
+	}
+
+	// The ID is 0 so that responses can be cached, as recommended by RFC
+	// 8484, section 4.1.
+	message := dnsmessage.Message{
+		Header: dnsmessage.Header{RecursionDesired: true},
+		Questions: []dnsmessage.Question{
+			{Name: questionName, Type: qtype, Class: dnsmessage.ClassINET},
+		},
+	}
+
+	packed, err := message.Pack()
+	if err != nil {
+		return "", "", err
+	}
+
+	query.Set("dns", base64.RawURLEncoding.EncodeToString(packed))
+	u.RawQuery = query.Encode()
+
+	return u.String(), "application/dns-message", nil
+}
+
+// dnsFQDN returns the name with a trailing dot.
+func dnsFQDN(name string) string {
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+
+	return name + "."
+}
+
+// dnsAnswer is the response code and the answer records of a response.
+type dnsAnswer struct {
+	rcode   dnsmessage.RCode
+	records []dnsRecordModel
+}
+
+// parseDNSWireMessage parses a response in DNS wire format.
+func parseDNSWireMessage(body []byte) (dnsAnswer, error) {
+	var parser dnsmessage.Parser
+
+	header, err := parser.Start(body)
+	if err != nil {
+		return dnsAnswer{}, err
+	}
+
+	if err := parser.SkipAllQuestions(); err != nil {
+		return dnsAnswer{}, err
+	}
+
+	answer := dnsAnswer{
+		rcode:   header.RCode,
+		records: []dnsRecordModel{},
+	}
+
+	for {
+		h, err := parser.AnswerHeader()
+		if err == dnsmessage.ErrSectionDone {
+			break
+		}
+
+		if err != nil {
+			return dnsAnswer{}, err
+		}
+
+		data, err := dnsWireRecordData(&parser, h.Type)
+		if err != nil {
+			return dnsAnswer{}, err
+		}
+
+		answer.records = append(answer.records, dnsRecordModel{
+			Name: types.StringValue(h.Name.String()),
+			Type: types.StringValue(dnsTypeName(h.Type)),
+			TTL:  types.Int64Value(int64(h.TTL)),
+			Data: types.StringValue(data),
+		})
+	}
+
+	return answer, nil
+}
+
+// dnsWireRecordData returns the data of the current record of the parser in
+// presentation format. The data of unsupported types is returned in the
+// generic format of RFC 3597.
+func dnsWireRecordData(parser *dnsmessage.Parser, t dnsmessage.Type) (string, error) {
+	switch t {
+	case dnsmessage.TypeA:
+		r, err := parser.AResource()
+		return netip.AddrFrom4(r.A).String(), err
+	case dnsmessage.TypeAAAA:
+		r, err := parser.AAAAResource()
+		return netip.AddrFrom16(r.AAAA).String(), err
+	case dnsmessage.TypeCNAME:
+		r, err := parser.CNAMEResource()
+		return r.CNAME.String(), err
+	case dnsmessage.TypeNS:
+		r, err := parser.NSResource()
+		return r.NS.String(), err
+	case dnsmessage.TypePTR:
+		r, err := parser.PTRResource()
+		return r.PTR.String(), err
+	case dnsmessage.TypeMX:
+		r, err := parser.MXResource()
+		return fmt.Sprintf("%d %s", r.Pref, r.MX), err
+	case dnsmessage.TypeSRV:
+		r, err := parser.SRVResource()
+		return fmt.Sprintf("%d %d %d %s", r.Priority, r.Weight, r.Port, r.Target), err
+	case dnsmessage.TypeSOA:
+		r, err := parser.SOAResource()
+		return fmt.Sprintf("%s %s %d %d %d %d %d", r.NS, r.MBox, r.Serial, r.Refresh, r.Retry, r.Expire, r.MinTTL), err
+	case dnsmessage.TypeTXT:
+		r, err := parser.TXTResource()
+		return strings.Join(r.TXT, ""), err
+	}
+
+	r, err := parser.UnknownResource()
+	if err != nil {
+		return "", err
+	}
+
+	// A CAA record is a flags octet, followed by the length of the tag, the
+	// tag and the value, according to RFC 8659, section 4.1.
+	if t == dnsTypes["CAA"] && len(r.Data) >= 2 && len(r.Data) >= 2+int(r.Data[1]) {
+		tagEnd := 2 + int(r.Data[1])
+
+		return fmt.Sprintf("%d %s %q", r.Data[0], r.Data[2:tagEnd], r.Data[tagEnd:]), nil
+	}
+
+	return fmt.Sprintf(`\# %d %x`, len(r.Data), r.Data), nil
+}
+
+// dnsJSONMessage is a response of the JSON API of DoH resolvers.
+type dnsJSONMessage struct {
+	Status int `json:"Status"`
+	Answer []struct {
+		Name string `json:"name"`
+		Type uint16 `json:"type"`
+		TTL  int64  `json:"TTL"`
+		Data string `json:"data"`
+	} `json:"Answer"`
+}
+
+// dnsTXTStrings matches the quoted character strings of TXT record data.
+var dnsTXTStrings = regexp.MustCompile(`"((?:[^"\\]|\\.)*)"`)
+
+// parseDNSJSONMessage parses a response of the JSON API.
+func parseDNSJSONMessage(body []byte) (dnsAnswer, error) {
+	var message dnsJSONMessage
+
+	if err := json.Unmarshal(body, &message); err != nil {
+		return dnsAnswer{}, err
+	}
+
+	answer := dnsAnswer{
+		rcode:   dnsmessage.RCode(message.Status),
+		records: []dnsRecordModel{},
+	}
+
+	for _, record := range message.Answer {
+		data := record.Data
+
+		// Some resolvers quote the character strings of TXT records, which
+		// are joined as with the wire format.
+		if dnsmessage.Type(record.Type) == dnsmessage.TypeTXT && strings.HasPrefix(data, `"`) {
+			var joined strings.Builder
+
+			for _, match := range dnsTXTStrings.FindAllStringSubmatch(data, -1) {
+				joined.WriteString(strings.ReplaceAll(match[1], `\"`, `"`))
+			}
+
+			data = joined.String()
+		}
+
+		answer.records = append(answer.records, dnsRecordModel{
+			Name: types.StringValue(record.Name),
+			Type: types.StringValue(dnsTypeName(dnsmessage.Type(record.Type))),
+			TTL:  types.Int64Value(record.TTL),
+			Data: types.StringValue(data),
+		})
+	}
+
+	return answer, nil
+}
+
+// dnsTypeName returns the name of the record type, or its generic name of
+// RFC 3597 if it is not supported, e.g. `TYPE65`.
+func dnsTypeName(t dnsmessage.Type) string {
+	for name, supported := range dnsTypes {
+		if supported == t {
+			return name
+		}
+	}
+
+	return fmt.Sprintf("TYPE%d", t)
+}
+
+// dnsRCodeName returns the mnemonic of the response code, e.g. `NXDOMAIN`.
+func dnsRCodeName(rcode dnsmessage.RCode) string {
+	switch rcode {
+	case dnsmessage.RCodeSuccess:
+		return "NOERROR"
+	case dnsmessage.RCodeFormatError:
+		return "FORMERR"
+	case dnsmessage.RCodeServerFailure:
+		return "SERVFAIL"
+	case dnsmessage.RCodeNameError:
+		return "NXDOMAIN"
+	case dnsmessage.RCodeNotImplemented:
+		return "NOTIMP"
+	case dnsmessage.RCodeRefused:
+		return "REFUSED"
+	}
+
+	return fmt.Sprintf("RCODE%d", rcode)
+}
+
+type dnsModelV0 struct {
+	ID             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	Type           types.String `tfsdk:"type"`
+	Endpoint       types.String `tfsdk:"endpoint"`
+	Format         types.String `tfsdk:"format"`
+	RequestHeaders types.Map    `tfsdk:"request_headers"`
+	RequestTimeout types.Int64  `tfsdk:"request_timeout_ms"`
+	CaCertificate  types.String `tfsdk:"ca_cert_pem"`
+	Insecure       types.Bool   `tfsdk:"insecure"`
+	RCode          types.String `tfsdk:"rcode"`
+	Records        types.List   `tfsdk:"records"`
+	Values         types.List   `tfsdk:"values"`
+}
+
+type dnsRecordModel struct {
+	Name types.String `tfsdk:"name"`
+	Type types.String `tfsdk:"type"`
+	TTL  types.Int64  `tfsdk:"ttl"`
+	Data types.String `tfsdk:"data"`
+}
+
+var dnsRecordAttrTypes = map[string]attr.Type{
+	"name": types.StringType,
+	"type": types.StringType,
+	"ttl":  types.Int64Type,
+	"data": types.StringType,
+}
@@ -0,0 +1,243 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// newTestDoHServer returns a DoH resolver which answers wire format queries
+// for example.com. with the given records, and NXDOMAIN for other names.
+func newTestDoHServer(t *testing.T, answer func(b *dnsmessage.Builder, name dnsmessage.Name) error) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != "application/dns-message" {
+			w.WriteHeader(http.StatusNotAcceptable)
+			return
+		}
+
+		query, err := base64.RawURLEncoding.DecodeString(r.URL.Query().Get("dns"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		var parser dnsmessage.Parser
+
+		header, err := parser.Start(query)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		question, err := parser.Question()
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		header.Response = true
+		if question.Name.String() != "example.com." {
+			header.RCode = dnsmessage.RCodeNameError
+		}
+
+		b := dnsmessage.NewBuilder(nil, header)
+		b.EnableCompression()
+
+		if err := b.StartQuestions(); err != nil {
+			t.Error(err)
+		}
+
+		if err := b.Question(question); err != nil {
+			t.Error(err)
+		}
+
+		if err := b.StartAnswers(); err != nil {
+			t.Error(err)
+		}
+
+		if header.RCode == dnsmessage.RCodeSuccess {
+			if err := answer(&b, question.Name); err != nil {
+				t.Error(err)
+			}
+		}
+
+		message, err := b.Finish()
+		if err != nil {
+			t.Error(err)
+		}
+
+		w.Header().Set("Content-Type", "application/dns-message")
+		_, _ = w.Write(message)
+	}))
+}
+
+func TestDNSDataSource_Wire(t *testing.T) {
+	testServer := newTestDoHServer(t, func(b *dnsmessage.Builder, name dnsmessage.Name) error {
+		header := dnsmessage.ResourceHeader{Name: name, Class: dnsmessage.ClassINET, TTL: 300}
+
+		if err := b.MXResource(header, dnsmessage.MXResource{Pref: 10, MX: dnsmessage.MustNewName("mail.example.com.")}); err != nil {
+			return err
+		}
+
+		header.TTL = 60
+
+		return b.MXResource(header, dnsmessage.MXResource{Pref: 20, MX: dnsmessage.MustNewName("backup.example.com.")})
+	})
+	defer testServer.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http_dns" "http_test" {
+								name     = "example.com"
+								type     = "MX"
+								endpoint = "%s/dns-query"
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http_dns.http_test", "id", "example.com/MX"),
+					resource.TestCheckResourceAttr("data.http_dns.http_test", "rcode", "NOERROR"),
+					resource.TestCheckResourceAttr("data.http_dns.http_test", "records.#", "2"),
+					resource.TestCheckResourceAttr("data.http_dns.http_test", "records.0.name", "example.com."),
+					resource.TestCheckResourceAttr("data.http_dns.http_test", "records.0.type", "MX"),
+					resource.TestCheckResourceAttr("data.http_dns.http_test", "records.0.ttl", "300"),
+					resource.TestCheckResourceAttr("data.http_dns.http_test", "records.0.data", "10 mail.example.com."),
+					resource.TestCheckResourceAttr("data.http_dns.http_test", "records.1.ttl", "60"),
+					resource.TestCheckResourceAttr("data.http_dns.http_test", "values.#", "2"),
+					resource.TestCheckResourceAttr("data.http_dns.http_test", "values.1", "20 backup.example.com."),
+				),
+			},
+		},
+	})
+}
+
+func TestDNSDataSource_WireCNAME(t *testing.T) {
+	testServer := newTestDoHServer(t, func(b *dnsmessage.Builder, name dnsmessage.Name) error {
+		target := dnsmessage.MustNewName("www.example.net.")
+
+		err := b.CNAMEResource(dnsmessage.ResourceHeader{Name: name, Class: dnsmessage.ClassINET, TTL: 300}, dnsmessage.CNAMEResource{CNAME: target})
+		if err != nil {
+			return err
+		}
+
+		return b.AResource(dnsmessage.ResourceHeader{Name: target, Class: dnsmessage.ClassINET, TTL: 30}, dnsmessage.AResource{A: [4]byte{192, 0, 2, 1}})
+	})
+	defer testServer.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http_dns" "http_test" {
+								name     = "example.com."
+								endpoint = "%s/dns-query"
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http_dns.http_test", "records.#", "2"),
+					resource.TestCheckResourceAttr("data.http_dns.http_test", "records.0.type", "CNAME"),
+					resource.TestCheckResourceAttr("data.http_dns.http_test", "records.0.data", "www.example.net."),
+					resource.TestCheckResourceAttr("data.http_dns.http_test", "records.1.name", "www.example.net."),
+					resource.TestCheckResourceAttr("data.http_dns.http_test", "values.#", "1"),
+					resource.TestCheckResourceAttr("data.http_dns.http_test", "values.0", "192.0.2.1"),
+				),
+			},
+		},
+	})
+}
+
+func TestDNSDataSource_WireNXDOMAIN(t *testing.T) {
+	testServer := newTestDoHServer(t, func(b *dnsmessage.Builder, name dnsmessage.Name) error {
+		return nil
+	})
+	defer testServer.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http_dns" "http_test" {
+								name     = "missing.example.com"
+								endpoint = "%s/dns-query"
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http_dns.http_test", "rcode", "NXDOMAIN"),
+					resource.TestCheckResourceAttr("data.http_dns.http_test", "records.#", "0"),
+					resource.TestCheckResourceAttr("data.http_dns.http_test", "values.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestDNSDataSource_JSON(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != "application/dns-json" || r.URL.Query().Get("name") != "example.com" || r.URL.Query().Get("type") != "16" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/dns-json")
+		_, _ = w.Write([]byte(`{
+			"Status": 0,
+			"Answer": [
+				{"name": "example.com.", "type": 16, "TTL": 3600, "data": "\"v=spf1 include:_spf.example.com \" \"-all\""}
+			]
+		}`))
+	}))
+	defer testServer.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http_dns" "http_test" {
+								name     = "example.com"
+								type     = "TXT"
+								format   = "json"
+								endpoint = "%s/resolve"
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http_dns.http_test", "rcode", "NOERROR"),
+					resource.TestCheckResourceAttr("data.http_dns.http_test", "records.0.ttl", "3600"),
+					resource.TestCheckResourceAttr("data.http_dns.http_test", "values.0", "v=spf1 include:_spf.example.com -all"),
+				),
+			},
+		},
+	})
+}
+
+func TestDNSDataSource_JSONServerFailure(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/dns-json")
+		_, _ = w.Write([]byte(`{"Status": 2}`))
+	}))
+	defer testServer.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http_dns" "http_test" {
+								name     = "example.com"
+								format   = "json"
+								endpoint = "%s/resolve"
+							}`, testServer.URL),
+				ExpectError: regexp.MustCompile(`could\s+not\s+resolve\s+the\s+A\s+records\s+of\s+"example.com":\s+SERVFAIL`),
+			},
+		},
+	})
+}
@@ -0,0 +1,267 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = (*fileDataSource)(nil)
+	_ datasource.DataSourceWithConfigure = (*fileDataSource)(nil)
+)
+
+func NewFileDataSource() datasource.DataSource {
+	return &fileDataSource{}
+}
+
+type fileDataSource struct {
+	providerData *providerData
+}
+
+func (d *fileDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	data, diags := configureProviderData(req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+	d.providerData = data
+}
+
+func (d *fileDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_file"
+}
+
+func (d *fileDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `
+The ` + "`http_file`" + ` data source downloads the response body of a GET request to a local file, such as a
+machine image, without holding it in memory or storing it in the state.
+
+The file is only written when the server responds with a 2xx-range status code. It is downloaded to a
+temporary file in the same directory first, so that an interrupted download does not leave a partial
+file at the destination.
+`,
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The URL used for the request.",
+				Computed:    true,
+			},
+
+			"url": schema.StringAttribute{
+				Description: "The URL of the file. Supported schemes are `http` and `https`.",
+				Required:    true,
+			},
+
+			"destination": schema.StringAttribute{
+				Description: "The path of the local file to which the response body is written. An existing file " +
+					"is replaced. Missing parent directories are created.",
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"request_headers": schema.MapAttribute{
+				Description: "A map of request header field names and values.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+
+			"request_timeout_ms": schema.Int64Attribute{
+				Description: "The request timeout in milliseconds, including the time to download the file.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"ca_cert_pem": schema.StringAttribute{
+				Description: "Certificate data of the Certificate Authority (CA) " +
+					"in [PEM (RFC 1421)](https://datatracker.ietf.org/doc/html/rfc1421) format.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("insecure")),
+				},
+			},
+
+			"insecure": schema.BoolAttribute{
+				Description: "Disables verification of the server's certificate chain and hostname. Defaults to `false`",
+				Optional:    true,
+			},
+
+			"status_code": schema.Int64Attribute{
+				Description: "The HTTP response status code.",
+				Computed:    true,
+			},
+
+			"path": schema.StringAttribute{
+				Description: "The absolute path of the downloaded file.",
+				Computed:    true,
+			},
+
+			"size": schema.Int64Attribute{
+				Description: "The size of the downloaded file in bytes.",
+				Computed:    true,
+			},
+
+			"sha256": schema.StringAttribute{
+				Description: "The hex-encoded SHA-256 checksum of the downloaded file.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *fileDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	// Export the spans of the requests, if tracing is configured.
+	defer d.providerData.flushSpans(ctx)
+
+	var model fileModelV0
+	diags := req.Config.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	requestURL := model.URL.ValueString()
+
+	destination, err := filepath.Abs(model.Destination.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("destination"),
+			"Invalid Destination",
+			fmt.Sprintf("The destination path could not be resolved: %s", err),
+		)
+		return
+	}
+
+	providerData := d.providerData.forURL(requestURL)
+
+	requestHeaders, diags := requestHeadersWithDefaults(providerData, model.RequestHeaders)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tr, diags := newTransport(providerData, model.CaCertificate, model.Insecure)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := &http.Client{
+		Transport: roundTripper(providerData, tr),
+		Timeout:   requestTimeout(providerData, model.RequestTimeout),
+	}
+
+	request, diags := newRequest(ctx, http.MethodGet, requestURL, nil, requestHeaders)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error making request",
+			fmt.Sprintf("Error making request: %s\n\nError code: %s", err, requestErrorCode(err)),
+		)
+		return
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		resp.Diagnostics.AddError(
+			"Unexpected Response Status Code",
+			fmt.Sprintf("The response from %s has the status %q, so the file was not downloaded.",
+				request.URL.Redacted(), response.Status),
+		)
+		return
+	}
+
+	size, checksum, err := downloadFile(response.Body, destination)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("destination"),
+			"Error Downloading File",
+			fmt.Sprintf("The response body of %s could not be written to %s: %s", request.URL.Redacted(), destination, err),
+		)
+		return
+	}
+
+	model.ID = types.StringValue(requestURL)
+	model.StatusCode = types.Int64Value(int64(response.StatusCode))
+	model.Path = types.StringValue(destination)
+	model.Size = types.Int64Value(size)
+	model.SHA256 = types.StringValue(checksum)
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+// downloadFile writes the body to a temporary file, which replaces the
+// destination once the body has been read completely, and returns the size
+// and hex-encoded SHA-256 checksum of the body.
+func downloadFile(body io.Reader, destination string) (int64, string, error) {
+	dir := filepath.Dir(destination)
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return 0, "", err
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(destination)+".*.tmp")
+	if err != nil {
+		return 0, "", err
+	}
+
+	// The temporary file no longer exists once it has been renamed.
+	defer os.Remove(tmp.Name())
+
+	hash := sha256.New()
+
+	size, err := io.Copy(io.MultiWriter(tmp, hash), body)
+	if err != nil {
+		tmp.Close()
+		return 0, "", err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return 0, "", err
+	}
+
+	if err := os.Rename(tmp.Name(), destination); err != nil {
+		return 0, "", err
+	}
+
+	return size, hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+type fileModelV0 struct {
+	ID             types.String `tfsdk:"id"`
+	URL            types.String `tfsdk:"url"`
+	Destination    types.String `tfsdk:"destination"`
+	RequestHeaders types.Map    `tfsdk:"request_headers"`
+	RequestTimeout types.Int64  `tfsdk:"request_timeout_ms"`
+	CaCertificate  types.String `tfsdk:"ca_cert_pem"`
+	Insecure       types.Bool   `tfsdk:"insecure"`
+	StatusCode     types.Int64  `tfsdk:"status_code"`
+	Path           types.String `tfsdk:"path"`
+	Size           types.Int64  `tfsdk:"size"`
+	SHA256         types.String `tfsdk:"sha256"`
+}
@@ -0,0 +1,101 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestFileDataSource_200(t *testing.T) {
+	content := strings.Repeat("machine image\n", 100000)
+	checksum := sha256.Sum256([]byte(content))
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/octet-stream")
+		_, _ = w.Write([]byte(content))
+	}))
+	defer testServer.Close()
+
+	destination := filepath.Join(t.TempDir(), "images", "image.raw")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http_file" "http_test" {
+								url         = "%s/image.raw"
+								destination = %q
+
+								request_headers = {
+									Authorization = "Bearer token"
+								}
+							}`, testServer.URL, destination),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http_file.http_test", "status_code", "200"),
+					resource.TestCheckResourceAttr("data.http_file.http_test", "path", destination),
+					resource.TestCheckResourceAttr("data.http_file.http_test", "size", strconv.Itoa(len(content))),
+					resource.TestCheckResourceAttr("data.http_file.http_test", "sha256", hex.EncodeToString(checksum[:])),
+					func(_ *terraform.State) error {
+						downloaded, err := os.ReadFile(destination)
+						if err != nil {
+							return err
+						}
+
+						if string(downloaded) != content {
+							return fmt.Errorf("expected the downloaded file to match the response body")
+						}
+
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestFileDataSource_404(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer testServer.Close()
+
+	destination := filepath.Join(t.TempDir(), "image.raw")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http_file" "http_test" {
+								url         = "%s/image.raw"
+								destination = %q
+							}`, testServer.URL, destination),
+				ExpectError: regexp.MustCompile(`has\s+the\s+status\s+"404\s+Not\s+Found",\s+so\s+the\s+file\s+was\s+not\s+downloaded`),
+			},
+		},
+	})
+
+	if _, err := os.Stat(destination); !os.IsNotExist(err) {
+		t.Errorf("expected no file at the destination, got: %v", err)
+	}
+}
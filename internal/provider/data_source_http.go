@@ -5,39 +5,82 @@ package provider
 
 import (
 	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"crypto/sha512"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
+	"net/http/httptrace"
 	"net/url"
+	"os"
+	"regexp"
+	"slices"
 	"strings"
+	"sync/atomic"
 	"time"
 	"unicode/utf8"
 
 	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"golang.org/x/net/http/httpproxy"
+	"github.com/ohler55/ojg/jp"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
-var _ datasource.DataSource = (*httpDataSource)(nil)
+// Checksum algorithms, configured via `expected_checksum`.
+const (
+	checksumSHA256 = "sha256"
+	checksumSHA512 = "sha512"
+	checksumMD5    = "md5"
+)
+
+// Invalid UTF-8 handling modes, configured via `sanitize_body`.
+const (
+	sanitizeBodyReplace = "replace"
+	sanitizeBodyStrip   = "strip"
+	sanitizeBodyError   = "error"
+)
+
+var (
+	_ datasource.DataSource                   = (*httpDataSource)(nil)
+	_ datasource.DataSourceWithConfigure      = (*httpDataSource)(nil)
+	_ datasource.DataSourceWithValidateConfig = (*httpDataSource)(nil)
+)
 
 func NewHttpDataSource() datasource.DataSource {
 	return &httpDataSource{}
 }
 
-type httpDataSource struct{}
+type httpDataSource struct {
+	providerData *providerData
+}
+
+func (d *httpDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	data, diags := configureProviderData(req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+	d.providerData = data
+}
 
 func (d *httpDataSource) Metadata(_ context.Context, _ datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	// This data source name unconventionally is equal to the provider name,
@@ -65,6 +108,10 @@ By default, there are no retries. Configuring the retry block will result in
 retries if an error is returned by the client (e.g., connection errors) or if 
 a 5xx-range (except 501) status code is received. For further details see 
 [go-retryablehttp](https://pkg.go.dev/github.com/hashicorp/go-retryablehttp).
+
+When the request fails, the error diagnostic ends with an ` + "`Error code: <code>`" + ` line
+classifying the failure as one of ` + "`dns_error`" + `, ` + "`tls_verify_failed`" + `, ` + "`timeout`" + `,
+` + "`connection_refused`" + `, ` + "`http_status`" + ` or ` + "`unknown`" + `.
 `,
 
 		Attributes: map[string]schema.Attribute{
@@ -74,8 +121,17 @@ a 5xx-range (except 501) status code is received. For further details see
 			},
 
 			"url": schema.StringAttribute{
-				Description: "The URL for the request. Supported schemes are `http` and `https`.",
-				Required:    true,
+				Description: "The URL for the request. Supported schemes are `http` and `https`. If `endpoint` is " +
+					"configured, the path, and optionally the query, which is appended to the base URL of the endpoint instead.",
+				Required: true,
+			},
+
+			"endpoint": schema.StringAttribute{
+				Description: "The name of a provider `endpoint`, whose base URL `url` is appended to.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
 			},
 
 			"method": schema.StringAttribute{
@@ -103,6 +159,18 @@ a 5xx-range (except 501) status code is received. For further details see
 				Optional:    true,
 			},
 
+			"send_content_length": schema.BoolAttribute{
+				Description: "Whether the length of `request_body` is sent in the `Content-Length` request header. " +
+					"When `false`, the request body is sent using chunked transfer encoding instead. Defaults to `true`.",
+				Optional: true,
+			},
+
+			"content_type": schema.StringAttribute{
+				Description: "The media type of the request body, sent as the `Content-Type` request header. " +
+					"A `Content-Type` entry in `request_headers` takes precedence over this value.",
+				Optional: true,
+			},
+
 			"request_timeout_ms": schema.Int64Attribute{
 				Description: "The request timeout in milliseconds.",
 				Optional:    true,
@@ -111,6 +179,128 @@ a 5xx-range (except 501) status code is received. For further details see
 				},
 			},
 
+			"success_status_codes": schema.ListAttribute{
+				Description: "A list of response status codes that are considered successful. If configured, the read " +
+					"fails with an error diagnostic when the response has any other status code. The diagnostic " +
+					"includes the problem details of an `" + problemMediaType + "` response. By default, the status " +
+					"code is not checked.",
+				ElementType: types.Int64Type,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueInt64sAre(int64validator.Between(100, 599)),
+				},
+			},
+
+			"connect_timeout_ms": schema.Int64Attribute{
+				Description: "The timeout for establishing the TCP connection in milliseconds, which distinguishes an " +
+					"unreachable host from a slow server. Defaults to the provider's `connect_timeout_ms`, or 30 seconds.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"tls_handshake_timeout_ms": schema.Int64Attribute{
+				Description: "The timeout for the TLS handshake in milliseconds. " +
+					"Defaults to the provider's `tls_handshake_timeout_ms`, or 10 seconds.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"response_header_timeout_ms": schema.Int64Attribute{
+				Description: "The timeout for receiving the response headers after the request has been sent in " +
+					"milliseconds. Defaults to the provider's `response_header_timeout_ms`, or no timeout.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"error_detail": schema.StringAttribute{
+				Description: "The format of the diagnostic detail when the request fails. " +
+					"`full` includes the complete error message, which may span multiple lines, followed by the error code. " +
+					"`compact` condenses the same information into a single line. Defaults to `full`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(errorDetailFull, errorDetailCompact),
+				},
+			},
+
+			"preflight": schema.StringAttribute{
+				Description: "Set to `tls_only` to only resolve the host, connect to it and perform a TLS handshake, " +
+					"without making an HTTP request or using a proxy. The result of the handshake is exported in `tls_handshake` " +
+					"and response attributes are `null`. Requires an `https` URL.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(preflightTLSOnly),
+				},
+			},
+
+			"tls_handshake": schema.ObjectAttribute{
+				Description: "The result of the TLS handshake performed when `preflight` is `tls_only`: the negotiated " +
+					"TLS `version`, `cipher_suite` and ALPN `negotiated_protocol`, and the subject and " +
+					"expiry (RFC 3339) of the server's leaf certificate.",
+				AttributeTypes: tlsHandshakeAttrTypes,
+				Computed:       true,
+			},
+
+			"decode_response_base64": schema.BoolAttribute{
+				Description: "Set to `true` to decode a base64 encoded response body, with or without padding and " +
+					"in standard or URL-safe encoding, before it is stored. Whitespace is ignored. The read fails if " +
+					"the response body is not valid base64. Attributes derived from the response body use the decoded " +
+					"content, except `response_size_bytes` and the checksum attributes. Defaults to `false`.",
+				Optional: true,
+			},
+
+			"sanitize_body": schema.StringAttribute{
+				Description: "How invalid UTF-8 sequences in the response body are handled before it is stored in " +
+					"`response_body` and `body`. `replace` replaces each invalid sequence with the Unicode replacement " +
+					"character (U+FFFD), `strip` removes invalid sequences and `error` fails the read. When not set, " +
+					"invalid sequences are replaced by Terraform and a warning is returned. `response_body_base64` " +
+					"and the checksum attributes always use the unmodified response body.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(sanitizeBodyReplace, sanitizeBodyStrip, sanitizeBodyError),
+				},
+			},
+
+			"request_body_bytes_sent": schema.Int64Attribute{
+				Description: "The number of bytes of `request_body` sent in the final request attempt. " +
+					"Upload progress is logged periodically at the `DEBUG` level. " +
+					"This is `null` if `request_body` is not configured.",
+				Computed: true,
+			},
+
+			"decode_content_encoding": schema.BoolAttribute{
+				Description: "Whether a response body encoded according to the `Content-Encoding` response header " +
+					"(`gzip`, `deflate` or `br`), for example because `Accept-Encoding` is set in `request_headers`, is " +
+					"decoded before it is stored. Set to `false` to keep the encoded bytes, for example in " +
+					"`response_body_base64`. Defaults to `true`.",
+				Optional: true,
+			},
+
+			"skip_response_body": schema.BoolAttribute{
+				Description: "Set to `true` to discard the response body without reading it, so that only the status code " +
+					"and headers are stored. This is useful for health checks where the body is large or irrelevant. " +
+					"Attributes derived from the response body are `null`. Defaults to `false`.",
+				Optional: true,
+				Validators: []validator.Bool{
+					boolvalidator.ConflictsWith(
+						path.MatchRoot("response_jsonpath"),
+						path.MatchRoot("next_cursor_jsonpath"),
+						path.MatchRoot("response_stream_jsonpath"),
+						path.MatchRoot("response_regex"),
+						path.MatchRoot("expected_response_body_regex"),
+						path.MatchRoot("split_documents"),
+						path.MatchRoot("expected_checksum"),
+						path.MatchRoot("paginate"),
+					),
+				},
+			},
+
 			"response_body": schema.StringAttribute{
 				Description: "The response body returned as a string.",
 				Computed:    true,
@@ -118,16 +308,151 @@ a 5xx-range (except 501) status code is received. For further details see
 
 			"body": schema.StringAttribute{
 				Description: "The response body returned as a string. " +
-					"**NOTE**: This is deprecated, use `response_body` instead.",
+					"**NOTE**: This is deprecated, use `response_body` instead. This is `null` if the provider `strict` " +
+					"mode is enabled.",
 				Computed:           true,
 				DeprecationMessage: "Use response_body instead",
 			},
 
+			"response_size_bytes": schema.Int64Attribute{
+				Description: "The number of bytes of the response body which were read, after decoding any " +
+					"`Content-Encoding`. Unlike the `Content-Length` response header, this is always the actual size. " +
+					"This is `null` if `skip_response_body` is `true`.",
+				Computed: true,
+			},
+
+			"response_body_lines": schema.ListAttribute{
+				Description: "The lines of `response_body`, split on newlines (`\\n` or `\\r\\n`). A trailing newline does not " +
+					"result in an empty last line.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+
 			"response_body_base64": schema.StringAttribute{
 				Description: "The response body encoded as base64 (standard) as defined in [RFC 4648](https://datatracker.ietf.org/doc/html/rfc4648#section-4).",
 				Computed:    true,
 			},
 
+			"response_body_sha256": schema.StringAttribute{
+				Description: "The SHA-256 checksum of the response body, encoded as lowercase hexadecimal. " +
+					"Unlike the `sha256` function applied to `response_body`, this is computed from the bytes " +
+					"of the response body and is suitable for binary content. The checksums are computed from the " +
+					"decoded response body, after `Content-Encoding` decoding unless `decode_content_encoding` is " +
+					"`false`, and before `decode_response_base64`.",
+				Computed: true,
+			},
+
+			"response_body_sha512": schema.StringAttribute{
+				Description: "The SHA-512 checksum of the response body, encoded as lowercase hexadecimal.",
+				Computed:    true,
+			},
+
+			"response_body_md5": schema.StringAttribute{
+				Description: "The MD5 checksum of the response body, encoded as lowercase hexadecimal.",
+				Computed:    true,
+			},
+
+			"response_body_json": schema.DynamicAttribute{
+				Description: "The response body decoded as a JSON document, using the same type conversions as the " +
+					"[`jsondecode`](https://developer.hashicorp.com/terraform/language/functions/jsondecode) function. " +
+					"This is `null` if the response body is not a valid JSON document.",
+				Computed: true,
+			},
+
+			"response_jsonpath": schema.MapAttribute{
+				Description: "A map of names to [JSONPath](https://datatracker.ietf.org/doc/html/rfc9535) expressions " +
+					"which are evaluated against the JSON response body. The results are exported in `extracted`.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+
+			"extracted": schema.MapAttribute{
+				Description: "A map of the names in `response_jsonpath` to the results of their expressions. " +
+					"A single string result is returned as is, any other single result is JSON encoded and multiple results " +
+					"are returned as a JSON encoded array. Names whose expression does not match anything are omitted.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+
+			"response_stream_jsonpath": schema.StringAttribute{
+				Description: "A [JSONPath](https://datatracker.ietf.org/doc/html/rfc9535) expression which is evaluated " +
+					"against each element of a JSON array response body while it is decoded, so that only the results " +
+					"are kept in memory and state. Each element is wrapped in a single-element array, so that filter " +
+					"expressions such as `$[?(@.status == 'active')]` select whole elements and " +
+					"`$[?(@.status == 'active')].name` selects values from them. The results are exported in " +
+					"`streamed_elements`. When this is set, the response body is not stored and `response_body`, " +
+					"`body`, `response_body_base64` and `response_body_json` are `null`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(
+						path.MatchRoot("response_jsonpath"),
+						path.MatchRoot("next_cursor_jsonpath"),
+						path.MatchRoot("response_regex"),
+						path.MatchRoot("expected_response_body_regex"),
+						path.MatchRoot("split_documents"),
+						path.MatchRoot("paginate"),
+					),
+				},
+			},
+
+			"streamed_elements": schema.ListAttribute{
+				Description: "The results of `response_stream_jsonpath` for each element of the JSON array response body, " +
+					"in order. Results are formatted in the same way as `extracted` and elements for which the expression " +
+					"does not match anything are omitted.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+
+			"response_regex": schema.StringAttribute{
+				Description: "A [regular expression](https://github.com/google/re2/wiki/Syntax) with named capture groups, " +
+					"such as `v(?P<version>[0-9.]+)`, which is matched against `response_body`. The values of the named " +
+					"groups in the first match are exported in `response_regex_matches`.",
+				Optional: true,
+			},
+
+			"expected_response_body_regex": schema.StringAttribute{
+				Description: "A [regular expression](https://github.com/google/re2/wiki/Syntax) which `response_body` " +
+					"must match, such as `healthy`. If configured, the read fails when the response body does not match " +
+					"and the error diagnostic includes an excerpt of the response body.",
+				Optional: true,
+			},
+
+			"response_regex_matches": schema.MapAttribute{
+				Description: "A map of the named capture groups in `response_regex` to their values in the first match. " +
+					"Groups which did not participate in the match are omitted and the map is empty if there is no match.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+
+			"split_documents": schema.StringAttribute{
+				Description: "Splits a response body containing multiple documents into `documents`. " +
+					"`yaml` splits a YAML stream (e.g., Kubernetes manifests) on `---` document separators. " +
+					"`json` splits concatenated or newline delimited JSON values.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(splitDocumentsYAML, splitDocumentsJSON),
+				},
+			},
+
+			"documents": schema.ListAttribute{
+				Description: "The documents in the response body, as split by `split_documents`. " +
+					"Documents which are empty are omitted. This is `null` if `split_documents` is not configured.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+
+			"next_cursor_jsonpath": schema.StringAttribute{
+				Description: "A [JSONPath](https://datatracker.ietf.org/doc/html/rfc9535) expression selecting the pagination " +
+					"cursor (e.g., a next page token or URL) in the JSON response body. The result is exported in `next_cursor`.",
+				Optional: true,
+			},
+
+			"next_cursor": schema.StringAttribute{
+				Description: "The pagination cursor selected by `next_cursor_jsonpath`. This is `null` if the expression " +
+					"does not match anything or matches a JSON `null` or empty string, which indicates there are no more pages.",
+				Computed: true,
+			},
+
 			"ca_cert_pem": schema.StringAttribute{
 				Description: "Certificate data of the Certificate Authority (CA) " +
 					"in [PEM (RFC 1421)](https://datatracker.ietf.org/doc/html/rfc1421) format.",
@@ -142,6 +467,83 @@ a 5xx-range (except 501) status code is received. For further details see
 				Optional:    true,
 			},
 
+			"pinned_public_keys": schema.ListAttribute{
+				Description: "A list of base64 encoded SHA-256 hashes of Subject Public Key Info (SPKI), prefixed " +
+					"with `sha256/`, such as `sha256/AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=`. If configured, the " +
+					"request fails unless the server certificate has one of the public keys, or is issued through the " +
+					"presented certificate chain by a certificate with one of them, and the certificate chain is not " +
+					"verified against certificate authorities. A hash can be computed with " +
+					"`openssl x509 -pubkey -noout | openssl pkey -pubin -outform der | openssl dgst -sha256 -binary " +
+					"| base64`.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.RegexMatches(
+						regexp.MustCompile(`^sha256/[A-Za-z0-9+/]{43}=$`),
+						"must be a base64 encoded SHA-256 hash prefixed with sha256/",
+					)),
+					listvalidator.ConflictsWith(path.MatchRoot("ca_cert_pem"), path.MatchRoot("insecure")),
+				},
+			},
+
+			"crl_pem": schema.StringAttribute{
+				Description: "One or more certificate revocation lists (CRLs) in PEM format. If configured, the " +
+					"request fails if a certificate presented by the server has been revoked. A CRL of the issuer of " +
+					"the server certificate is required, while other certificates of the chain are only checked if " +
+					"a CRL of their issuer is configured. The CRLs must be signed by the issuers and must not be expired.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("crl_url")),
+				},
+			},
+
+			"crl_url": schema.StringAttribute{
+				Description: "The URL of a certificate revocation list (CRL) in PEM or DER format, which is downloaded " +
+					"with a GET request before each read and is used in the same way as `crl_pem`.",
+				Optional: true,
+			},
+
+			"require_ocsp_staple": schema.BoolAttribute{
+				Description: "Requires the server to staple an OCSP response to the TLS handshake, which must be " +
+					"signed by the issuer of the server certificate and must report the certificate as good. " +
+					"Defaults to `false`.",
+				Optional: true,
+			},
+
+			"ocsp_check": schema.BoolAttribute{
+				Description: "Checks the revocation status of the server certificate with OCSP. The response " +
+					"stapled by the server is used if present, otherwise the OCSP responder listed in the " +
+					"certificate is queried. The read fails if the certificate has been revoked or its status is " +
+					"unknown. Defaults to `false`.",
+				Optional: true,
+			},
+
+			"tls_cipher_suites": schema.ListAttribute{
+				Description: "The cipher suites which may be negotiated with TLS 1.2 and earlier, in the names of " +
+					"the [Go crypto/tls package](https://pkg.go.dev/crypto/tls#pkg-constants), such as " +
+					"`TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256`. Suites with known security issues are supported for " +
+					"legacy devices. The cipher suites of TLS 1.3 are not configurable. Defaults to the provider " +
+					"`tls_cipher_suites`, or the Go defaults.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.OneOf(cipherSuiteNames()...)),
+				},
+			},
+
+			"tls_renegotiation": schema.StringAttribute{
+				Description: "Whether the server may request TLS renegotiation, which some servers require to " +
+					"request a client certificate after the handshake. One of `never`, `once` or `freely`. " +
+					"Renegotiation is only supported by TLS 1.2 and earlier. Defaults to the provider " +
+					"`tls_renegotiation`, or `never`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("never", "once", "freely"),
+				},
+			},
+
 			"response_headers": schema.MapAttribute{
 				Description: `A map of response header field names and values.` +
 					` Duplicate headers are concatenated according to [RFC2616](https://www.w3.org/Protocols/rfc2616/rfc2616-sec4.html#sec4.2).`,
@@ -149,6 +551,133 @@ a 5xx-range (except 501) status code is received. For further details see
 				Computed:    true,
 			},
 
+			"response_headers_all": schema.MapAttribute{
+				Description: "A map of response header field names and lists of their values. " +
+					"Unlike `response_headers`, duplicate headers are not concatenated, which preserves values containing commas.",
+				ElementType: types.ListType{ElemType: types.StringType},
+				Computed:    true,
+			},
+
+			"response_headers_lowercase": schema.MapAttribute{
+				Description: "A map of lowercase response header field names and values, which allows headers to be " +
+					"looked up consistently regardless of the casing used by the server (e.g., `content-type`). " +
+					"Duplicate headers are concatenated in the same way as `response_headers`.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+
+			"links": schema.MapAttribute{
+				Description: "A map of relation types (e.g., `next`, `prev` and `last`) to target URLs, parsed from " +
+					"the `Link` response headers as described in [RFC 8288](https://www.rfc-editor.org/rfc/rfc8288). " +
+					"Relative URLs are resolved against the request URL. If multiple links have the same relation type, " +
+					"the first one is used.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+
+			"pages": schema.ListAttribute{
+				Description: "The response bodies of all pages, including the first page, if `paginate` is configured.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+
+			"items": schema.StringAttribute{
+				Description: "The items of all pages, as selected by `paginate.items_jsonpath` or the elements of JSON " +
+					"array pages, merged into a single JSON encoded array. This is `null` if `paginate` is not configured " +
+					"or the items of a page could not be determined.",
+				Computed: true,
+			},
+
+			"tls_version": schema.StringAttribute{
+				Description: "The TLS version negotiated for the final request attempt, such as `TLS 1.3`. " +
+					"This is `null` if TLS is not used.",
+				Computed: true,
+			},
+
+			"tls_cipher_suite": schema.StringAttribute{
+				Description: "The TLS cipher suite negotiated for the final request attempt, such as " +
+					"`TLS_AES_128_GCM_SHA256`. This is `null` if TLS is not used.",
+				Computed: true,
+			},
+
+			"http_protocol": schema.StringAttribute{
+				Description: "The HTTP protocol version of the response, such as `HTTP/1.1` or `HTTP/2.0`.",
+				Computed:    true,
+			},
+
+			"capture_har": schema.BoolAttribute{
+				Description: "Set to `true` to export a [HAR 1.2](http://www.softwareishard.com/blog/har-12-spec/) " +
+					"document recording the final request and response in `har`, which can be imported into browser " +
+					"developer tools. The document includes the request headers and bodies, which may contain credentials. " +
+					"Defaults to `false`.",
+				Optional: true,
+			},
+
+			"har_output_path": schema.StringAttribute{
+				Description: "The path of a file to which a [HAR 1.2](http://www.softwareishard.com/blog/har-12-spec/) " +
+					"document recording the final request and response is written, with permissions `0600`.",
+				Optional: true,
+			},
+
+			"har": schema.StringAttribute{
+				Description: "The HAR 1.2 document recording the final request and response, if `capture_har` is `true`.",
+				Computed:    true,
+			},
+
+			"attempts_made": schema.Int64Attribute{
+				Description: "The number of attempts made for the request, including the final attempt. " +
+					"This is greater than 1 if the request was retried.",
+				Computed: true,
+			},
+
+			"total_retry_wait_ms": schema.Int64Attribute{
+				Description: "The total delay between retry attempts in milliseconds.",
+				Computed:    true,
+			},
+
+			"retry_after_honored": schema.BoolAttribute{
+				Description: "Whether the delay before a retry was taken from the `Retry-After` header of a 429 or 503 " +
+					"response, rather than exponential backoff.",
+				Computed: true,
+			},
+
+			"timing": schema.ObjectAttribute{
+				Description: "The duration in milliseconds of the phases of the final request attempt: `dns_ms`, " +
+					"`connect_ms` and `tls_ms` (which are `0` if an existing connection is reused or a phase does not " +
+					"apply), `time_to_first_byte_ms` and `total_ms`, which includes reading the response body.",
+				AttributeTypes: timingAttrTypes,
+				Computed:       true,
+			},
+
+			"response_content_type": schema.StringAttribute{
+				Description: "The media type of the `Content-Type` response header, in lowercase and without parameters " +
+					"(e.g., `text/html`). This is `null` if the header is missing or cannot be parsed.",
+				Computed: true,
+			},
+
+			"response_content_type_params": schema.MapAttribute{
+				Description: "A map of the parameters of the `Content-Type` response header, such as `charset` or " +
+					"`boundary`, with lowercase names.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+
+			"problem": schema.ObjectAttribute{
+				Description: "The [RFC 7807](https://www.rfc-editor.org/rfc/rfc7807) problem details (`type`, `title`, " +
+					"`status`, `detail` and `instance`) of a response with the `" + problemMediaType + "` content type. " +
+					"This is `null` for other responses or if the response body is not a JSON object.",
+				AttributeTypes: problemAttrTypes,
+				Computed:       true,
+			},
+
+			"response_trailers": schema.MapAttribute{
+				Description: "A map of response trailer field names and values, which are sent after the response body " +
+					"(e.g., with chunked transfer encoding). Duplicate trailers are concatenated in the same way as " +
+					"`response_headers`. This is empty if `skip_response_body` is `true`.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+
 			"status_code": schema.Int64Attribute{
 				Description: `The HTTP response status code.`,
 				Computed:    true,
@@ -156,40 +685,147 @@ a 5xx-range (except 501) status code is received. For further details see
 		},
 
 		Blocks: map[string]schema.Block{
-			"retry": schema.SingleNestedBlock{
-				Description: "Retry request configuration. By default there are no retries. Configuring this block will result in " +
-					"retries if an error is returned by the client (e.g., connection errors) or if a 5xx-range (except 501) status code is received. " +
-					"For further details see [go-retryablehttp](https://pkg.go.dev/github.com/hashicorp/go-retryablehttp).",
+			"result_validation": schema.ListNestedBlock{
+				Description: "Conditions on the response, which are evaluated in order after the response is read. " +
+					"The read fails with the `error_message` of the first condition with `error` severity that does not " +
+					"hold, followed by the offending value. A condition checks the status code against `status_codes` " +
+					"and/or a value against `equals` or `regex`. The value is the `header` response header, the result of the " +
+					"`jsonpath` expression, formatted in the same way as `response_jsonpath`, or otherwise the response body.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"status_codes": schema.ListAttribute{
+							Description: "A list of allowed response status codes.",
+							ElementType: types.Int64Type,
+							Optional:    true,
+							Validators: []validator.List{
+								listvalidator.SizeAtLeast(1),
+								listvalidator.ValueInt64sAre(int64validator.Between(100, 599)),
+							},
+						},
+						"header": schema.StringAttribute{
+							Description: "The name of the response header whose value is checked. Duplicate headers " +
+								"are concatenated in the same way as `response_headers`.",
+							Optional: true,
+							Validators: []validator.String{
+								stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("jsonpath")),
+							},
+						},
+						"jsonpath": schema.StringAttribute{
+							Description: "The JSONPath expression whose result is checked.",
+							Optional:    true,
+						},
+						"equals": schema.StringAttribute{
+							Description: "The expected value.",
+							Optional:    true,
+							Validators: []validator.String{
+								stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("regex")),
+							},
+						},
+						"regex": schema.StringAttribute{
+							Description: "A regular expression ([RE2 syntax](https://github.com/google/re2/wiki/Syntax)) " +
+								"which the value must match.",
+							Optional: true,
+						},
+						"error_message": schema.StringAttribute{
+							Description: "The summary of the diagnostic if the condition does not hold.",
+							Required:    true,
+						},
+						"severity": schema.StringAttribute{
+							Description: "The severity of the diagnostic if the condition does not hold. An `error` fails " +
+								"the read, while a `warning` is reported without failing the read and the remaining " +
+								"conditions are still evaluated. Defaults to `error`.",
+							Optional: true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(severityError, severityWarning),
+							},
+						},
+					},
+				},
+			},
+
+			"paginate": schema.SingleNestedBlock{
+				Description: "Pagination configuration. If configured, subsequent pages are requested using the same " +
+					"method, headers and body and their bodies are exported in `pages`. Response attributes other than " +
+					"`pages` and `items` relate to the first page. The read fails if a subsequent page does not return " +
+					"a 2xx-range status code.",
+				Validators: []validator.Object{
+					objectvalidator.AlsoRequires(path.MatchRelative().AtName("mode")),
+				},
 				Attributes: map[string]schema.Attribute{
-					"attempts": schema.Int64Attribute{
-						Description: "The number of times the request is to be retried. For example, if 2 is specified, the request will be tried a maximum of 3 times.",
-						Optional:    true,
-						Validators: []validator.Int64{
-							int64validator.AtLeast(0),
+					"mode": schema.StringAttribute{
+						Description: "How the next page is determined. `link_header` follows the `next` link of the " +
+							"`Link` response header. `next_jsonpath` follows the URL selected by `next_jsonpath` in the " +
+							"JSON response body. `page_param` increments the `page_param` query parameter until an " +
+							"empty page is returned.",
+						Optional: true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(paginateLinkHeader, paginateNextJSONPath, paginatePageParam),
 						},
 					},
-					"min_delay_ms": schema.Int64Attribute{
-						Description: "The minimum delay between retry requests in milliseconds.",
+					"max_pages": schema.Int64Attribute{
+						Description: "The maximum number of pages requested, including the first page. Defaults to `10`.",
 						Optional:    true,
 						Validators: []validator.Int64{
-							int64validator.AtLeast(0),
+							int64validator.AtLeast(1),
 						},
 					},
-					"max_delay_ms": schema.Int64Attribute{
-						Description: "The maximum delay between retry requests in milliseconds.",
+					"next_jsonpath": schema.StringAttribute{
+						Description: "A [JSONPath](https://datatracker.ietf.org/doc/html/rfc9535) expression selecting the " +
+							"URL of the next page in the JSON response body, which may be relative to the URL of the page. " +
+							"Pagination stops when it does not match a non-empty string. Required when `mode` is `next_jsonpath`.",
+						Optional: true,
+					},
+					"page_param": schema.StringAttribute{
+						Description: "The query parameter containing the page number when `mode` is `page_param`. " +
+							"A missing parameter is treated as page `1`. Defaults to `page`.",
+						Optional: true,
+					},
+					"items_jsonpath": schema.StringAttribute{
+						Description: "A [JSONPath](https://datatracker.ietf.org/doc/html/rfc9535) expression selecting the " +
+							"items in the JSON body of each page, such as `$.items`. A single array result is flattened. " +
+							"If not configured, each page must be a JSON array.",
+						Optional: true,
+					},
+				},
+			},
+
+			"expected_checksum": schema.SingleNestedBlock{
+				Description: "The expected checksum of the response body. If configured, the read fails when the " +
+					"checksum of the response body does not match, which allows downloaded content to be verified.",
+				Validators: []validator.Object{
+					objectvalidator.AlsoRequires(
+						path.MatchRelative().AtName("algorithm"),
+						path.MatchRelative().AtName("value"),
+					),
+				},
+				Attributes: map[string]schema.Attribute{
+					"algorithm": schema.StringAttribute{
+						Description: "The checksum algorithm. Supported values are `sha256`, `sha512` and `md5`.",
 						Optional:    true,
-						Validators: []validator.Int64{
-							int64validator.AtLeast(0),
-							int64validator.AtLeastSumOf(path.MatchRelative().AtParent().AtName("min_delay_ms")),
+						Validators: []validator.String{
+							stringvalidator.OneOf(checksumSHA256, checksumSHA512, checksumMD5),
 						},
 					},
+					"value": schema.StringAttribute{
+						Description: "The expected checksum, encoded as hexadecimal.",
+						Optional:    true,
+					},
 				},
 			},
+
+			"retry": retryBlock(
+				"Retry request configuration. By default there are no retries. Configuring this block will result in " +
+					"retries if an error is returned by the client (e.g., connection errors) or if a 5xx-range (except 501) status code is received. " +
+					"If a 429 or 503 response includes a `Retry-After` header, the delay it specifies is used, bounded by `max_delay_ms`. " +
+					"Requests using any method, including `POST` requests and their body, are retried unless `retry_non_idempotent` is `false`. " +
+					"If this block is not configured, the provider's `retry` block is used. " +
+					"For further details see [go-retryablehttp](https://pkg.go.dev/github.com/hashicorp/go-retryablehttp).",
+			),
 		},
 	}
 }
 
-func (d *httpDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+func (d *httpDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
 	var model modelV0
 	diags := req.Config.Get(ctx, &model)
 	resp.Diagnostics.Append(diags...)
@@ -197,59 +833,331 @@ func (d *httpDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 
-	requestURL := model.URL.ValueString()
-	method := model.Method.ValueString()
-	requestHeaders := model.RequestHeaders
+	resp.Diagnostics.Append(validateContentType(model)...)
+	resp.Diagnostics.Append(validateResponseJSONPath(ctx, model)...)
+	resp.Diagnostics.Append(validateResponseRegex(model)...)
+	resp.Diagnostics.Append(validateExpectedResponseBodyRegex(model)...)
+	resp.Diagnostics.Append(validatePaginate(ctx, model)...)
+	resp.Diagnostics.Append(validateRetry(ctx, model.Retry)...)
+	resp.Diagnostics.Append(validateResultValidation(ctx, model)...)
+	resp.Diagnostics.Append(validateCRLPEM(model)...)
+}
 
-	if method == "" {
-		method = "GET"
-	}
+func validateCRLPEM(model modelV0) diag.Diagnostics {
+	var diags diag.Diagnostics
 
-	caCertificate := model.CaCertificate
+	if model.CRLPEM.IsNull() || model.CRLPEM.IsUnknown() {
+		return diags
+	}
 
-	tr, ok := http.DefaultTransport.(*http.Transport)
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Error configuring http transport",
-			"Error http: Can't configure http transport.",
+	if _, err := parseCRLs([]byte(model.CRLPEM.ValueString())); err != nil {
+		diags.AddAttributeError(
+			path.Root("crl_pem"),
+			"Invalid Certificate Revocation List",
+			fmt.Sprintf("The certificate revocation list could not be parsed: %s", err),
 		)
-		return
 	}
 
-	// Prevent issues with multiple data source configurations modifying the shared transport.
-	clonedTr := tr.Clone()
+	return diags
+}
+
+func validateContentType(model modelV0) diag.Diagnostics {
+	var diags diag.Diagnostics
 
-	// Prevent issues with tests caching the proxy configuration.
-	clonedTr.Proxy = func(req *http.Request) (*url.URL, error) {
-		return httpproxy.FromEnvironment().ProxyFunc()(req.URL)
+	if model.ContentType.IsNull() || model.ContentType.IsUnknown() {
+		return diags
 	}
 
-	if clonedTr.TLSClientConfig == nil {
-		clonedTr.TLSClientConfig = &tls.Config{}
+	mediaType, _, err := mime.ParseMediaType(model.ContentType.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("content_type"),
+			"Invalid Content Type",
+			fmt.Sprintf("The content_type value could not be parsed as a media type: %s", err),
+		)
+		return diags
 	}
 
-	if !model.Insecure.IsNull() {
-		if clonedTr.TLSClientConfig == nil {
-			clonedTr.TLSClientConfig = &tls.Config{}
+	if model.RequestBody.IsNull() || model.RequestBody.IsUnknown() {
+		return diags
+	}
+
+	if isJSONDocument(model.RequestBody.ValueString()) && !isJSONMediaType(mediaType) {
+		diags.AddAttributeWarning(
+			path.Root("content_type"),
+			"Content Type Does Not Match Request Body",
+			fmt.Sprintf("The request_body appears to be a JSON document, but content_type is %q. "+
+				"Servers may reject or misinterpret the request. Consider using \"application/json\".", mediaType),
+		)
+	}
+
+	return diags
+}
+
+func validateResponseJSONPath(ctx context.Context, model modelV0) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !model.NextCursorJSONPath.IsNull() && !model.NextCursorJSONPath.IsUnknown() {
+		if _, err := jp.ParseString(model.NextCursorJSONPath.ValueString()); err != nil {
+			diags.AddAttributeError(
+				path.Root("next_cursor_jsonpath"),
+				"Invalid JSONPath Expression",
+				fmt.Sprintf("The JSONPath expression %q could not be parsed: %s", model.NextCursorJSONPath.ValueString(), err),
+			)
+		}
+	}
+
+	if !model.ResponseStreamJSONPath.IsNull() && !model.ResponseStreamJSONPath.IsUnknown() {
+		if _, err := jp.ParseString(model.ResponseStreamJSONPath.ValueString()); err != nil {
+			diags.AddAttributeError(
+				path.Root("response_stream_jsonpath"),
+				"Invalid JSONPath Expression",
+				fmt.Sprintf("The JSONPath expression %q could not be parsed: %s", model.ResponseStreamJSONPath.ValueString(), err),
+			)
+		}
+	}
+
+	if model.ResponseJSONPath.IsNull() || model.ResponseJSONPath.IsUnknown() {
+		return diags
+	}
+
+	for name, value := range model.ResponseJSONPath.Elements() {
+		expression, ok := value.(types.String)
+		if !ok || expression.IsNull() || expression.IsUnknown() {
+			continue
+		}
+
+		if _, err := jp.ParseString(expression.ValueString()); err != nil {
+			diags.AddAttributeError(
+				path.Root("response_jsonpath").AtMapKey(name),
+				"Invalid JSONPath Expression",
+				fmt.Sprintf("The JSONPath expression %q could not be parsed: %s", expression.ValueString(), err),
+			)
+		}
+	}
+
+	return diags
+}
+
+func validateResponseRegex(model modelV0) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if model.ResponseRegex.IsNull() || model.ResponseRegex.IsUnknown() {
+		return diags
+	}
+
+	re, err := regexp.Compile(model.ResponseRegex.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("response_regex"),
+			"Invalid Regular Expression",
+			fmt.Sprintf("The regular expression could not be compiled: %s", err),
+		)
+		return diags
+	}
+
+	for _, name := range re.SubexpNames() {
+		if name != "" {
+			return diags
 		}
-		clonedTr.TLSClientConfig.InsecureSkipVerify = model.Insecure.ValueBool()
 	}
 
-	// Use `ca_cert_pem` cert pool
-	if !caCertificate.IsNull() {
-		caCertPool := x509.NewCertPool()
-		if ok := caCertPool.AppendCertsFromPEM([]byte(caCertificate.ValueString())); !ok {
+	diags.AddAttributeError(
+		path.Root("response_regex"),
+		"Invalid Regular Expression",
+		"The regular expression must contain at least one named capture group, such as (?P<name>...).",
+	)
+
+	return diags
+}
+
+func validateResultValidation(ctx context.Context, model modelV0) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if model.ResultValidation.IsNull() || model.ResultValidation.IsUnknown() {
+		return diags
+	}
+
+	var conditions []resultValidationModel
+
+	diags.Append(model.ResultValidation.ElementsAs(ctx, &conditions, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	for i, condition := range conditions {
+		diags.Append(condition.validate(path.Root("result_validation").AtListIndex(i))...)
+	}
+
+	return diags
+}
+
+func validateExpectedResponseBodyRegex(model modelV0) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if model.ExpectedResponseBodyRegex.IsNull() || model.ExpectedResponseBodyRegex.IsUnknown() {
+		return diags
+	}
+
+	if _, err := regexp.Compile(model.ExpectedResponseBodyRegex.ValueString()); err != nil {
+		diags.AddAttributeError(
+			path.Root("expected_response_body_regex"),
+			"Invalid Regular Expression",
+			fmt.Sprintf("The regular expression could not be compiled: %s", err),
+		)
+	}
+
+	return diags
+}
+
+func validatePaginate(ctx context.Context, model modelV0) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if model.Paginate.IsNull() || model.Paginate.IsUnknown() {
+		return diags
+	}
+
+	var paginate paginateModel
+
+	diags.Append(model.Paginate.As(ctx, &paginate, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return diags
+	}
+
+	if paginate.Mode.ValueString() == paginateNextJSONPath && paginate.NextJSONPath.IsNull() {
+		diags.AddAttributeError(
+			path.Root("paginate").AtName("next_jsonpath"),
+			"Missing Attribute Configuration",
+			"The next_jsonpath attribute must be configured when mode is \"next_jsonpath\".",
+		)
+	}
+
+	for name, expression := range map[string]types.String{
+		"next_jsonpath":  paginate.NextJSONPath,
+		"items_jsonpath": paginate.ItemsJSONPath,
+	} {
+		if expression.IsNull() || expression.IsUnknown() {
+			continue
+		}
+
+		if _, err := jp.ParseString(expression.ValueString()); err != nil {
+			diags.AddAttributeError(
+				path.Root("paginate").AtName(name),
+				"Invalid JSONPath Expression",
+				fmt.Sprintf("The JSONPath expression %q could not be parsed: %s", expression.ValueString(), err),
+			)
+		}
+	}
+
+	return diags
+}
+
+func (d *httpDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model modelV0
+	diags := req.Config.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	requestURL := model.URL.ValueString()
+	method := model.Method.ValueString()
+
+	if !model.Endpoint.IsNull() {
+		var err error
+
+		requestURL, err = endpointURL(d.providerData, model.Endpoint.ValueString(), requestURL)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("endpoint"),
+				"Unknown Endpoint",
+				fmt.Sprintf("The request URL could not be determined: %s", err),
+			)
+			return
+		}
+	}
+
+	// The request attempts are traced as children of the span of the read.
+	ctx, span := d.providerData.tracer().Start(ctx, "data.http",
+		trace.WithAttributes(semconv.URLFull(redactedURL(requestURL))),
+	)
+	defer func() {
+		endSpan(span, resp.Diagnostics)
+		d.providerData.flushSpans(ctx)
+	}()
+
+	providerData := d.providerData.forURL(requestURL).withTimeouts(
+		millisecondsDuration(model.ConnectTimeout),
+		millisecondsDuration(model.TLSHandshakeTimeout),
+		millisecondsDuration(model.ResponseHeaderTimeout),
+	)
+
+	requestHeaders, diags := requestHeadersWithDefaults(providerData, model.RequestHeaders)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	crls, diags := certificateRevocationLists(ctx, providerData, model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData = providerData.withCRLs(crls).withOCSP(model.RequireOCSPStaple.ValueBool(), model.OCSPCheck.ValueBool())
+
+	cipherSuites, diags := cipherSuiteIDs(ctx, model.TLSCipherSuites)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var pinnedPublicKeys []string
+
+	diags = model.PinnedPublicKeys.ElementsAs(ctx, &pinnedPublicKeys, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData = providerData.withCipherSuites(cipherSuites).
+		withRenegotiation(model.TLSRenegotiation).
+		withPinnedPublicKeys(pinnedPublicKeys)
+
+	if method == "" {
+		method = "GET"
+	}
+
+	clonedTr, diags := newTransport(providerData, model.CaCertificate, model.Insecure)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Preflight.ValueString() == preflightTLSOnly {
+		timeout := requestTimeout(providerData, model.RequestTimeout)
+
+		state, err := tlsPreflight(ctx, clonedTr, requestURL, timeout)
+		if err != nil {
 			resp.Diagnostics.AddError(
-				"Error configuring TLS client",
-				"Error tls: Can't add the CA certificate to certificate pool. Only PEM encoded certificates are supported.",
+				"Error performing TLS preflight",
+				fmt.Sprintf("Error performing TLS preflight: %s\n\nError code: %s", err, requestErrorCode(err)),
 			)
 			return
 		}
 
-		if clonedTr.TLSClientConfig == nil {
-			clonedTr.TLSClientConfig = &tls.Config{}
+		tlsHandshake, diags := tlsHandshakeValue(state)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
 		}
-		clonedTr.TLSClientConfig.RootCAs = caCertPool
+
+		model.ID = types.StringValue(requestURL)
+		model.TLSHandshake = tlsHandshake
+
+		diags = resp.State.Set(ctx, model)
+		resp.Diagnostics.Append(diags...)
+		return
 	}
 
 	var retry retryModel
@@ -260,30 +1168,51 @@ func (d *httpDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		if resp.Diagnostics.HasError() {
 			return
 		}
+	} else if d.providerData != nil && d.providerData.retry != nil {
+		retry = *d.providerData.retry
 	}
 
 	retryClient := retryablehttp.NewClient()
-	retryClient.HTTPClient.Transport = clonedTr
-
-	var timeout time.Duration
+	retryClient.HTTPClient.Transport = roundTripper(providerData, clonedTr)
 
-	if model.RequestTimeout.ValueInt64() > 0 {
-		timeout = time.Duration(model.RequestTimeout.ValueInt64()) * time.Millisecond
-		retryClient.HTTPClient.Timeout = timeout
-	}
+	timeout := requestTimeout(providerData, model.RequestTimeout)
+	retryClient.HTTPClient.Timeout = timeout
 
 	retryClient.Logger = levelledLogger{ctx}
-	retryClient.RetryMax = int(retry.Attempts.ValueInt64())
 
-	if !retry.MinDelay.IsNull() && !retry.MinDelay.IsUnknown() && retry.MinDelay.ValueInt64() >= 0 {
-		retryClient.RetryWaitMin = time.Duration(retry.MinDelay.ValueInt64()) * time.Millisecond
+	var retryAfterHonored atomic.Bool
+	var attemptsMade, retryWait atomic.Int64
+
+	resp.Diagnostics.Append(configureRetry(ctx, retryClient, retry, retryOptions{
+		method:                method,
+		decodeContentEncoding: model.DecodeContentEncoding.IsNull() || model.DecodeContentEncoding.ValueBool(),
+		retryBudget:           providerData.retryBudget,
+		retryAfterHonored:     &retryAfterHonored,
+		retryWait:             &retryWait,
+	})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	retryClient.RequestLogHook = func(_ retryablehttp.Logger, _ *http.Request, _ int) {
+		attemptsMade.Add(1)
 	}
 
-	if !retry.MaxDelay.IsNull() && !retry.MaxDelay.IsUnknown() && retry.MaxDelay.ValueInt64() >= 0 {
-		retryClient.RetryWaitMax = time.Duration(retry.MaxDelay.ValueInt64()) * time.Millisecond
+	requestCtx := ctx
+
+	var maxElapsedTime time.Duration
+
+	if !retry.MaxElapsedTime.IsNull() {
+		maxElapsedTime = time.Duration(retry.MaxElapsedTime.ValueInt64()) * time.Millisecond
+
+		var cancel context.CancelFunc
+		requestCtx, cancel = context.WithTimeout(ctx, maxElapsedTime)
+		defer cancel()
 	}
 
-	request, err := retryablehttp.NewRequestWithContext(ctx, method, requestURL, nil)
+	timer := &requestTimer{}
+
+	request, err := retryablehttp.NewRequestWithContext(httptrace.WithClientTrace(requestCtx, timer.clientTrace()), method, requestURL, nil)
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -293,8 +1222,17 @@ func (d *httpDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 
+	var requestBodyProgress *progressReader
+
 	if !model.RequestBody.IsNull() {
-		err = request.SetBody(strings.NewReader(model.RequestBody.ValueString()))
+		requestBody := model.RequestBody.ValueString()
+
+		// A new reader is created for each attempt, so that the bytes sent
+		// are those of the final attempt.
+		err = request.SetBody(retryablehttp.ReaderFunc(func() (io.Reader, error) {
+			requestBodyProgress = newProgressReader(ctx, strings.NewReader(requestBody), int64(len(requestBody)))
+			return requestBodyProgress, nil
+		}))
 
 		if err != nil {
 			resp.Diagnostics.AddError(
@@ -304,6 +1242,16 @@ func (d *httpDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 
 			return
 		}
+
+		if !model.SendContentLength.IsNull() && !model.SendContentLength.ValueBool() {
+			// An unknown content length results in the body being sent with chunked transfer encoding.
+			request.ContentLength = -1
+			request.TransferEncoding = []string{"chunked"}
+		}
+	}
+
+	if !model.ContentType.IsNull() {
+		request.Header.Set("Content-Type", model.ContentType.ValueString())
 	}
 
 	for name, value := range requestHeaders.Elements() {
@@ -320,8 +1268,49 @@ func (d *httpDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		}
 	}
 
+	retryClient.ErrorHandler = retryErrorHandler(request.Request)
+
+	requestStarted := time.Now()
+
 	response, err := retryClient.Do(request)
 	if err != nil {
+		errorCode := requestErrorCode(err)
+
+		if errors.Is(err, errRetryBudgetExhausted) {
+			resp.Diagnostics.AddError(
+				"Retry Budget Exhausted",
+				fmt.Sprintf("The request was not retried, as the provider retry_budget of %d retries has been spent "+
+					"by earlier requests. This usually indicates a widespread outage.\n\nError making request: %s\n\n"+
+					"Error code: %s", providerData.retryBudget.size, err, errorCode),
+			)
+			return
+		}
+
+		// The retry deadline applies to all attempts, so report it rather
+		// than the request timeout.
+		if maxElapsedTime > 0 && ctx.Err() == nil && errors.Is(requestCtx.Err(), context.DeadlineExceeded) {
+			elapsed := time.Since(requestStarted).Round(time.Millisecond)
+
+			detail := fmt.Sprintf("Error making request: retry deadline of %s exceeded after %s: %s\n\nError code: %s",
+				maxElapsedTime, elapsed, err, errorCode)
+
+			if model.ErrorDetail.ValueString() == errorDetailCompact {
+				detail = fmt.Sprintf("%s (max_elapsed_time_ms: %d, elapsed: %s)",
+					compactRequestError(err, errorCode, 0), maxElapsedTime.Milliseconds(), elapsed)
+			}
+
+			resp.Diagnostics.AddError("Error making request", detail)
+			return
+		}
+
+		if model.ErrorDetail.ValueString() == errorDetailCompact {
+			resp.Diagnostics.AddError(
+				"Error making request",
+				compactRequestError(err, errorCode, timeout),
+			)
+			return
+		}
+
 		target := &url.Error{}
 		if errors.As(err, &target) {
 			if target.Timeout() {
@@ -333,7 +1322,7 @@ func (d *httpDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 
 				resp.Diagnostics.AddError(
 					"Error making request",
-					detail,
+					fmt.Sprintf("%s\n\nError code: %s", detail, errorCode),
 				)
 				return
 			}
@@ -341,36 +1330,513 @@ func (d *httpDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 
 		resp.Diagnostics.AddError(
 			"Error making request",
-			fmt.Sprintf("Error making request: %s", err),
+			fmt.Sprintf("Error making request: %s\n\nError code: %s", err, errorCode),
 		)
 		return
 	}
 
 	defer response.Body.Close()
 
-	bytes, err := io.ReadAll(response.Body)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error reading response body",
-			fmt.Sprintf("Error reading response body: %s", err),
-		)
-		return
-	}
-
-	if !utf8.Valid(bytes) {
-		resp.Diagnostics.AddWarning(
-			"Response body is not recognized as UTF-8",
-			"Terraform may not properly handle the response_body if the contents are binary.",
-		)
-	}
+	// Subsequent pages are requested with the same client, so the attempts
+	// and delays of the first request are recorded now.
+	model.AttemptsMade = types.Int64Value(attemptsMade.Load())
+	providerData.metrics.recordRetries(request.URL.Host, attemptsMade.Load()-1)
+	model.TotalRetryWait = types.Int64Value(time.Duration(retryWait.Load()).Milliseconds())
 
-	responseBody := string(bytes)
-	responseBodyBase64Std := base64.StdEncoding.EncodeToString(bytes)
+	span.SetAttributes(
+		attribute.Int64("http.attempts", attemptsMade.Load()),
+		attribute.Int64("http.retry_wait_ms", model.TotalRetryWait.ValueInt64()),
+		semconv.HTTPResponseStatusCode(response.StatusCode),
+	)
 
 	responseHeaders := make(map[string]string)
+	responseHeadersLowercase := make(map[string]string)
 	for k, v := range response.Header {
 		// Concatenate according to RFC9110 https://www.rfc-editor.org/rfc/rfc9110.html#section-5.2
 		responseHeaders[k] = strings.Join(v, ", ")
+
+		lowercaseKey := strings.ToLower(k)
+		if existing, ok := responseHeadersLowercase[lowercaseKey]; ok {
+			responseHeadersLowercase[lowercaseKey] = existing + ", " + responseHeaders[k]
+		} else {
+			responseHeadersLowercase[lowercaseKey] = responseHeaders[k]
+		}
+	}
+
+	var responseReader io.Reader = response.Body
+
+	if !model.SkipResponseBody.ValueBool() && (model.DecodeContentEncoding.IsNull() || model.DecodeContentEncoding.ValueBool()) {
+		decodingReader, err := contentDecodingReader(response.Header, response.Body)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error decoding response body",
+				fmt.Sprintf("The response body could not be decoded according to its Content-Encoding header: %s. "+
+					"Set decode_content_encoding to false to store the encoded response body.", err),
+			)
+			return
+		}
+
+		responseReader = decodingReader
+	}
+
+	var responseSize byteCounter
+
+	sha256Hash, sha512Hash, md5Hash := sha256.New(), sha512.New(), md5.New()
+	body := io.TeeReader(responseReader, io.MultiWriter(sha256Hash, sha512Hash, md5Hash, &responseSize))
+
+	responseBody := types.StringNull()
+	responseBodyBase64 := types.StringNull()
+	responseBodyLines := types.ListNull(types.StringType)
+	responseBodyJSON := types.DynamicNull()
+	streamedElements := types.ListNull(types.StringType)
+
+	var document interface{}
+
+	// responseBodyBytes is the response body, if it is stored.
+	var responseBodyBytes []byte
+
+	switch {
+	case model.SkipResponseBody.ValueBool():
+		// The body is not read and is discarded when it is closed.
+	case !model.ResponseStreamJSONPath.IsNull():
+		elements, err := streamJSONArray(body, model.ResponseStreamJSONPath.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error streaming response body",
+				fmt.Sprintf("Error decoding the response body as a JSON array: %s", err),
+			)
+			return
+		}
+
+		// Read any trailing data so that the checksums cover the whole body.
+		if _, err := io.Copy(io.Discard, body); err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading response body",
+				fmt.Sprintf("Error reading response body: %s", err),
+			)
+			return
+		}
+
+		streamedElements, diags = types.ListValueFrom(ctx, types.StringType, elements)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	default:
+		bytes, err := io.ReadAll(body)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading response body",
+				fmt.Sprintf("Error reading response body: %s", err),
+			)
+			return
+		}
+
+		responseBodyBytes = bytes
+
+		if model.DecodeResponseBase64.ValueBool() {
+			bytes, err = decodeBase64(string(bytes))
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("decode_response_base64"),
+					"Error decoding response body",
+					fmt.Sprintf("The response body from %s could not be decoded as base64: %s", requestURL, err),
+				)
+				return
+			}
+		}
+
+		responseBodyString := string(bytes)
+
+		if !utf8.Valid(bytes) {
+			switch model.SanitizeBody.ValueString() {
+			case sanitizeBodyReplace:
+				responseBodyString = strings.ToValidUTF8(responseBodyString, string(utf8.RuneError))
+			case sanitizeBodyStrip:
+				responseBodyString = strings.ToValidUTF8(responseBodyString, "")
+			case sanitizeBodyError:
+				resp.Diagnostics.AddAttributeError(
+					path.Root("sanitize_body"),
+					"Response body is not valid UTF-8",
+					fmt.Sprintf("The response body from %s contains invalid UTF-8 sequences. "+
+						"Use response_body_base64 to handle binary content.", requestURL),
+				)
+				return
+			default:
+				resp.Diagnostics.AddWarning(
+					"Response body is not recognized as UTF-8",
+					"Terraform may not properly handle the response_body if the contents are binary.",
+				)
+			}
+		}
+
+		responseBody = types.StringValue(responseBodyString)
+
+		responseBodyLines, diags = types.ListValueFrom(ctx, types.StringType, splitLines(responseBodyString))
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		responseBodyBase64 = types.StringValue(base64.StdEncoding.EncodeToString(bytes))
+
+		responseBodyJSON, diags = jsonDynamicValue(ctx, bytes)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if !model.ResponseJSONPath.IsNull() || !model.NextCursorJSONPath.IsNull() {
+			document, err = parseJSONPathDocument(bytes)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error evaluating JSONPath expressions",
+					fmt.Sprintf("The response body could not be parsed as JSON: %s", err),
+				)
+				return
+			}
+		}
+	}
+
+	timings := timer.timings(time.Now())
+
+	span.SetAttributes(timingAttributes(timings)...)
+
+	timing, diags := timings.value()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if model.CaptureHAR.ValueBool() || !model.HAROutputPath.IsNull() {
+		var requestBody *string
+		if !model.RequestBody.IsNull() {
+			requestBody = model.RequestBody.ValueStringPointer()
+		}
+
+		har, err := harExchange(response.Request, requestBody, response, responseBodyBytes, timings)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error capturing HAR",
+				fmt.Sprintf("Error encoding the HAR document: %s", err),
+			)
+			return
+		}
+
+		if !model.HAROutputPath.IsNull() {
+			if err := os.WriteFile(model.HAROutputPath.ValueString(), har, 0o600); err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("har_output_path"),
+					"Error writing HAR file",
+					fmt.Sprintf("Error writing the HAR document: %s", err),
+				)
+				return
+			}
+		}
+
+		if model.CaptureHAR.ValueBool() {
+			model.HAR = types.StringValue(string(har))
+		}
+	}
+
+	if !model.SuccessStatusCodes.IsNull() {
+		var successStatusCodes []int64
+
+		resp.Diagnostics.Append(model.SuccessStatusCodes.ElementsAs(ctx, &successStatusCodes, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if !slices.Contains(successStatusCodes, int64(response.StatusCode)) {
+			detail := fmt.Sprintf("The response from %s has the status %q, which is not one of the configured "+
+				"success status codes.", requestURL, response.Status)
+
+			if details, ok := responseProblemDetails(response.Header, responseBodyBytes); ok {
+				detail += "\n\n" + details.String()
+			}
+
+			resp.Diagnostics.AddAttributeError(
+				path.Root("success_status_codes"),
+				"Unexpected Response Status Code",
+				detail,
+			)
+			return
+		}
+	}
+
+	if !model.ExpectedResponseBodyRegex.IsNull() {
+		pattern, err := regexp.Compile(model.ExpectedResponseBodyRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("expected_response_body_regex"),
+				"Invalid Regular Expression",
+				fmt.Sprintf("The regular expression could not be compiled: %s", err),
+			)
+			return
+		}
+
+		if !pattern.MatchString(responseBody.ValueString()) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("expected_response_body_regex"),
+				"Unexpected Response Body",
+				fmt.Sprintf("The response body from %s does not match %q.\n\nResponse body excerpt:\n%s",
+					requestURL, pattern, bodyExcerpt(responseBody.ValueString())),
+			)
+			return
+		}
+	}
+
+	if !model.ResultValidation.IsNull() {
+		var conditions []resultValidationModel
+
+		resp.Diagnostics.Append(model.ResultValidation.ElementsAs(ctx, &conditions, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		for i, condition := range conditions {
+			failure, diags := condition.check(ctx, response, responseBody.ValueString())
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			if failure == "" {
+				continue
+			}
+
+			detail := fmt.Sprintf("%s\n\nURL: %s", failure, response.Request.URL.Redacted())
+
+			if condition.Severity.ValueString() == severityWarning {
+				resp.Diagnostics.AddAttributeWarning(
+					path.Root("result_validation").AtListIndex(i),
+					condition.ErrorMessage.ValueString(),
+					detail,
+				)
+				continue
+			}
+
+			resp.Diagnostics.AddAttributeError(
+				path.Root("result_validation").AtListIndex(i),
+				condition.ErrorMessage.ValueString(),
+				detail,
+			)
+			return
+		}
+	}
+
+	pages := types.ListNull(types.StringType)
+	items := types.StringNull()
+
+	if !model.Paginate.IsNull() {
+		var paginate paginateModel
+
+		resp.Diagnostics.Append(model.Paginate.As(ctx, &paginate, basetypes.ObjectAsOptions{})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		paginator, err := newPaginator(paginate)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("paginate"),
+				"Invalid JSONPath Expression",
+				fmt.Sprintf("The JSONPath expression could not be parsed: %s", err),
+			)
+			return
+		}
+
+		var requestBody []byte
+		if !model.RequestBody.IsNull() {
+			requestBody = []byte(model.RequestBody.ValueString())
+		}
+
+		pageResponse, pageBody := response, responseBody.ValueString()
+
+		for {
+			nextURL, err := paginator.addPage(pageResponse, pageBody)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error paginating response",
+					fmt.Sprintf("Error paginating response: %s", err),
+				)
+				return
+			}
+
+			if nextURL == "" {
+				break
+			}
+
+			tflog.Debug(ctx, "Requesting next page", map[string]interface{}{
+				"url":  nextURL,
+				"page": len(paginator.pages) + 1,
+			})
+
+			pageResponse, pageBody, err = fetchPage(ctx, retryClient, request, requestBody, nextURL, model.DecodeContentEncoding.IsNull() || model.DecodeContentEncoding.ValueBool())
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error paginating response",
+					fmt.Sprintf("Error requesting page %d (%s): %s", len(paginator.pages)+1, nextURL, err),
+				)
+				return
+			}
+		}
+
+		pages, diags = types.ListValueFrom(ctx, types.StringType, paginator.pages)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		items, err = paginator.itemsJSON()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error paginating response",
+				fmt.Sprintf("Error encoding items: %s", err),
+			)
+			return
+		}
+	}
+
+	checksums := map[string]types.String{
+		checksumSHA256: types.StringNull(),
+		checksumSHA512: types.StringNull(),
+		checksumMD5:    types.StringNull(),
+	}
+
+	if !model.SkipResponseBody.ValueBool() {
+		checksums[checksumSHA256] = types.StringValue(fmt.Sprintf("%x", sha256Hash.Sum(nil)))
+		checksums[checksumSHA512] = types.StringValue(fmt.Sprintf("%x", sha512Hash.Sum(nil)))
+		checksums[checksumMD5] = types.StringValue(fmt.Sprintf("%x", md5Hash.Sum(nil)))
+	}
+
+	if !model.ExpectedChecksum.IsNull() {
+		var expectedChecksum expectedChecksumModel
+
+		resp.Diagnostics.Append(model.ExpectedChecksum.As(ctx, &expectedChecksum, basetypes.ObjectAsOptions{})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		algorithm := expectedChecksum.Algorithm.ValueString()
+		actual := checksums[algorithm].ValueString()
+
+		if !strings.EqualFold(strings.TrimSpace(expectedChecksum.Value.ValueString()), actual) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("expected_checksum").AtName("value"),
+				"Response Checksum Mismatch",
+				fmt.Sprintf("The %s checksum of the response body from %s does not match the expected checksum.\n\n"+
+					"Expected: %s\nActual:   %s", algorithm, requestURL, expectedChecksum.Value.ValueString(), actual),
+			)
+			return
+		}
+	}
+
+	documents := types.ListNull(types.StringType)
+
+	if !model.SplitDocuments.IsNull() {
+		var split []string
+
+		switch model.SplitDocuments.ValueString() {
+		case splitDocumentsYAML:
+			split = splitYAMLDocuments(responseBody.ValueString())
+		case splitDocumentsJSON:
+			split, err = splitJSONDocuments([]byte(responseBody.ValueString()))
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("split_documents"),
+					"Error splitting response body",
+					fmt.Sprintf("The response body could not be split into JSON documents: %s", err),
+				)
+				return
+			}
+		}
+
+		documents, diags = types.ListValueFrom(ctx, types.StringType, split)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	regexMatches := make(map[string]string)
+
+	if !model.ResponseRegex.IsNull() {
+		re, err := regexp.Compile(model.ResponseRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("response_regex"),
+				"Invalid Regular Expression",
+				fmt.Sprintf("The regular expression could not be compiled: %s", err),
+			)
+			return
+		}
+
+		if match := re.FindStringSubmatchIndex(responseBody.ValueString()); match != nil {
+			for i, name := range re.SubexpNames() {
+				if name == "" || match[2*i] < 0 {
+					continue
+				}
+
+				regexMatches[name] = responseBody.ValueString()[match[2*i]:match[2*i+1]]
+			}
+		}
+	}
+
+	extracted := make(map[string]string)
+
+	if !model.ResponseJSONPath.IsNull() {
+		var expressions map[string]string
+		resp.Diagnostics.Append(model.ResponseJSONPath.ElementsAs(ctx, &expressions, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		for name, expression := range expressions {
+			result, ok, err := jsonPathString(document, expression)
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("response_jsonpath").AtMapKey(name),
+					"Error evaluating JSONPath expression",
+					fmt.Sprintf("Error evaluating JSONPath expression %q: %s", expression, err),
+				)
+				return
+			}
+
+			if ok {
+				extracted[name] = result
+			}
+		}
+	}
+
+	nextCursor := types.StringNull()
+
+	if !model.NextCursorJSONPath.IsNull() {
+		result, ok, err := jsonPathString(document, model.NextCursorJSONPath.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("next_cursor_jsonpath"),
+				"Error evaluating JSONPath expression",
+				fmt.Sprintf("Error evaluating JSONPath expression %q: %s", model.NextCursorJSONPath.ValueString(), err),
+			)
+			return
+		}
+
+		// Both JSON null and empty strings are commonly used to signal the last page.
+		if ok && result != "" && result != "null" {
+			nextCursor = types.StringValue(result)
+		}
+	}
+
+	extractedState, diags := types.MapValueFrom(ctx, types.StringType, extracted)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	regexMatchesState, diags := types.MapValueFrom(ctx, types.StringType, regexMatches)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	respHeadersState, diags := types.MapValueFrom(ctx, types.StringType, responseHeaders)
@@ -379,38 +1845,322 @@ func (d *httpDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 
+	respHeadersLowercaseState, diags := types.MapValueFrom(ctx, types.StringType, responseHeadersLowercase)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Trailers are only available once the response body has been read.
+	responseTrailers := make(map[string]string)
+	for k, v := range response.Trailer {
+		if len(v) > 0 {
+			responseTrailers[k] = strings.Join(v, ", ")
+		}
+	}
+
+	responseContentType := types.StringNull()
+	responseContentTypeParams := make(map[string]string)
+
+	if contentType := response.Header.Get("Content-Type"); contentType != "" {
+		mediaType, params, err := mime.ParseMediaType(contentType)
+		if err == nil || errors.Is(err, mime.ErrInvalidMediaParameter) {
+			responseContentType = types.StringValue(mediaType)
+			responseContentTypeParams = params
+		}
+	}
+
+	problem := types.ObjectNull(problemAttrTypes)
+
+	if !model.SkipResponseBody.ValueBool() {
+		if details, ok := responseProblemDetails(response.Header, responseBodyBytes); ok {
+			problem, diags = details.value()
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+	}
+
+	respContentTypeParamsState, diags := types.MapValueFrom(ctx, types.StringType, responseContentTypeParams)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	respTrailersState, diags := types.MapValueFrom(ctx, types.StringType, responseTrailers)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	linksState, diags := types.MapValueFrom(ctx, types.StringType, parseLinkHeaders(response.Header.Values("Link"), response.Request.URL))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	respHeadersAllState, diags := types.MapValueFrom(ctx, types.ListType{ElemType: types.StringType}, response.Header)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if requestBodyProgress != nil {
+		model.RequestBodyBytesSent = types.Int64Value(requestBodyProgress.BytesRead())
+	}
+
 	model.ID = types.StringValue(requestURL)
 	model.ResponseHeaders = respHeadersState
-	model.ResponseBody = types.StringValue(responseBody)
-	model.Body = types.StringValue(responseBody)
-	model.ResponseBodyBase64 = types.StringValue(responseBodyBase64Std)
+	model.ResponseHeadersAll = respHeadersAllState
+	model.ResponseHeadersLowercase = respHeadersLowercaseState
+	model.ResponseTrailers = respTrailersState
+	model.ResponseContentType = responseContentType
+	model.ResponseContentTypeParams = respContentTypeParamsState
+	model.Problem = problem
+	model.ResponseBody = responseBody
+	model.Body = responseBody
+
+	if d.providerData != nil && d.providerData.strict {
+		model.Body = types.StringNull()
+	}
+
+	model.ResponseBodyBase64 = responseBodyBase64
+	model.ResponseBodyLines = responseBodyLines
+
+	if !model.SkipResponseBody.ValueBool() {
+		model.ResponseSizeBytes = types.Int64Value(int64(responseSize))
+	}
+
+	model.ResponseBodySHA256 = checksums[checksumSHA256]
+	model.ResponseBodySHA512 = checksums[checksumSHA512]
+	model.ResponseBodyMD5 = checksums[checksumMD5]
+	model.ResponseBodyJSON = responseBodyJSON
+	model.StreamedElements = streamedElements
+	model.Extracted = extractedState
+	model.ResponseRegexMatches = regexMatchesState
+	model.Documents = documents
+	model.NextCursor = nextCursor
+	model.Links = linksState
+	model.Pages = pages
+	model.Items = items
+	model.Timing = timing
+	model.RetryAfterHonored = types.BoolValue(retryAfterHonored.Load())
+	model.HTTPProtocol = types.StringValue(response.Proto)
+
+	if response.TLS != nil {
+		model.TLSVersion = types.StringValue(tls.VersionName(response.TLS.Version))
+		model.TLSCipherSuite = types.StringValue(tls.CipherSuiteName(response.TLS.CipherSuite))
+	}
+
 	model.StatusCode = types.Int64Value(int64(response.StatusCode))
 
 	diags = resp.State.Set(ctx, model)
 	resp.Diagnostics.Append(diags...)
 }
 
+// certificateRevocationLists returns the CRLs configured via `crl_pem`, or
+// downloaded from `crl_url`.
+func certificateRevocationLists(ctx context.Context, p *providerData, model modelV0) ([]*x509.RevocationList, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var data []byte
+
+	switch {
+	case !model.CRLPEM.IsNull():
+		data = []byte(model.CRLPEM.ValueString())
+	case !model.CRLURL.IsNull():
+		tr, transportDiags := newTransport(p, model.CaCertificate, model.Insecure)
+		diags.Append(transportDiags...)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		response, body, requestDiags := doGetRequest(ctx, roundTripper(p, tr), model.CRLURL.ValueString(), types.MapNull(types.StringType), requestTimeout(p, model.RequestTimeout))
+		diags.Append(requestDiags...)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		if response.StatusCode != http.StatusOK {
+			diags.AddAttributeError(
+				path.Root("crl_url"),
+				"Error Downloading Certificate Revocation List",
+				fmt.Sprintf("The request to %s returned the status code %d.", model.CRLURL.ValueString(), response.StatusCode),
+			)
+			return nil, diags
+		}
+
+		data = body
+	default:
+		return nil, diags
+	}
+
+	crls, err := parseCRLs(data)
+	if err == nil && len(crls) == 0 {
+		err = errors.New("no CRL found")
+	}
+
+	if err != nil {
+		diags.AddError(
+			"Invalid Certificate Revocation List",
+			fmt.Sprintf("The certificate revocation list could not be parsed: %s", err),
+		)
+		return nil, diags
+	}
+
+	return crls, diags
+}
+
 type modelV0 struct {
-	ID                 types.String `tfsdk:"id"`
-	URL                types.String `tfsdk:"url"`
-	Method             types.String `tfsdk:"method"`
-	RequestHeaders     types.Map    `tfsdk:"request_headers"`
-	RequestBody        types.String `tfsdk:"request_body"`
-	RequestTimeout     types.Int64  `tfsdk:"request_timeout_ms"`
-	Retry              types.Object `tfsdk:"retry"`
-	ResponseHeaders    types.Map    `tfsdk:"response_headers"`
-	CaCertificate      types.String `tfsdk:"ca_cert_pem"`
-	Insecure           types.Bool   `tfsdk:"insecure"`
-	ResponseBody       types.String `tfsdk:"response_body"`
-	Body               types.String `tfsdk:"body"`
-	ResponseBodyBase64 types.String `tfsdk:"response_body_base64"`
-	StatusCode         types.Int64  `tfsdk:"status_code"`
+	ID                        types.String  `tfsdk:"id"`
+	URL                       types.String  `tfsdk:"url"`
+	Endpoint                  types.String  `tfsdk:"endpoint"`
+	Method                    types.String  `tfsdk:"method"`
+	RequestHeaders            types.Map     `tfsdk:"request_headers"`
+	RequestBody               types.String  `tfsdk:"request_body"`
+	ContentType               types.String  `tfsdk:"content_type"`
+	SendContentLength         types.Bool    `tfsdk:"send_content_length"`
+	RequestBodyBytesSent      types.Int64   `tfsdk:"request_body_bytes_sent"`
+	RequestTimeout            types.Int64   `tfsdk:"request_timeout_ms"`
+	ConnectTimeout            types.Int64   `tfsdk:"connect_timeout_ms"`
+	TLSHandshakeTimeout       types.Int64   `tfsdk:"tls_handshake_timeout_ms"`
+	ResponseHeaderTimeout     types.Int64   `tfsdk:"response_header_timeout_ms"`
+	ResultValidation          types.List    `tfsdk:"result_validation"`
+	Retry                     types.Object  `tfsdk:"retry"`
+	ExpectedChecksum          types.Object  `tfsdk:"expected_checksum"`
+	ResponseHeaders           types.Map     `tfsdk:"response_headers"`
+	ResponseHeadersAll        types.Map     `tfsdk:"response_headers_all"`
+	ResponseHeadersLowercase  types.Map     `tfsdk:"response_headers_lowercase"`
+	Problem                   types.Object  `tfsdk:"problem"`
+	ResponseTrailers          types.Map     `tfsdk:"response_trailers"`
+	ResponseContentType       types.String  `tfsdk:"response_content_type"`
+	ResponseContentTypeParams types.Map     `tfsdk:"response_content_type_params"`
+	CaCertificate             types.String  `tfsdk:"ca_cert_pem"`
+	Insecure                  types.Bool    `tfsdk:"insecure"`
+	DecodeContentEncoding     types.Bool    `tfsdk:"decode_content_encoding"`
+	SkipResponseBody          types.Bool    `tfsdk:"skip_response_body"`
+	DecodeResponseBase64      types.Bool    `tfsdk:"decode_response_base64"`
+	SanitizeBody              types.String  `tfsdk:"sanitize_body"`
+	ResponseBody              types.String  `tfsdk:"response_body"`
+	Body                      types.String  `tfsdk:"body"`
+	ResponseSizeBytes         types.Int64   `tfsdk:"response_size_bytes"`
+	ResponseBodyLines         types.List    `tfsdk:"response_body_lines"`
+	ResponseBodyBase64        types.String  `tfsdk:"response_body_base64"`
+	ResponseBodySHA256        types.String  `tfsdk:"response_body_sha256"`
+	ResponseBodySHA512        types.String  `tfsdk:"response_body_sha512"`
+	ResponseBodyMD5           types.String  `tfsdk:"response_body_md5"`
+	ResponseBodyJSON          types.Dynamic `tfsdk:"response_body_json"`
+	ResponseJSONPath          types.Map     `tfsdk:"response_jsonpath"`
+	Extracted                 types.Map     `tfsdk:"extracted"`
+	SuccessStatusCodes        types.List    `tfsdk:"success_status_codes"`
+	ErrorDetail               types.String  `tfsdk:"error_detail"`
+	ResponseStreamJSONPath    types.String  `tfsdk:"response_stream_jsonpath"`
+	StreamedElements          types.List    `tfsdk:"streamed_elements"`
+	ResponseRegex             types.String  `tfsdk:"response_regex"`
+	ExpectedResponseBodyRegex types.String  `tfsdk:"expected_response_body_regex"`
+	ResponseRegexMatches      types.Map     `tfsdk:"response_regex_matches"`
+	SplitDocuments            types.String  `tfsdk:"split_documents"`
+	Documents                 types.List    `tfsdk:"documents"`
+	NextCursorJSONPath        types.String  `tfsdk:"next_cursor_jsonpath"`
+	NextCursor                types.String  `tfsdk:"next_cursor"`
+	Preflight                 types.String  `tfsdk:"preflight"`
+	TLSHandshake              types.Object  `tfsdk:"tls_handshake"`
+	Paginate                  types.Object  `tfsdk:"paginate"`
+	Pages                     types.List    `tfsdk:"pages"`
+	Items                     types.String  `tfsdk:"items"`
+	Links                     types.Map     `tfsdk:"links"`
+	TLSVersion                types.String  `tfsdk:"tls_version"`
+	TLSCipherSuite            types.String  `tfsdk:"tls_cipher_suite"`
+	TLSCipherSuites           types.List    `tfsdk:"tls_cipher_suites"`
+	TLSRenegotiation          types.String  `tfsdk:"tls_renegotiation"`
+	PinnedPublicKeys          types.List    `tfsdk:"pinned_public_keys"`
+	CRLPEM                    types.String  `tfsdk:"crl_pem"`
+	CRLURL                    types.String  `tfsdk:"crl_url"`
+	RequireOCSPStaple         types.Bool    `tfsdk:"require_ocsp_staple"`
+	OCSPCheck                 types.Bool    `tfsdk:"ocsp_check"`
+	HTTPProtocol              types.String  `tfsdk:"http_protocol"`
+	Timing                    types.Object  `tfsdk:"timing"`
+	AttemptsMade              types.Int64   `tfsdk:"attempts_made"`
+	TotalRetryWait            types.Int64   `tfsdk:"total_retry_wait_ms"`
+	RetryAfterHonored         types.Bool    `tfsdk:"retry_after_honored"`
+	CaptureHAR                types.Bool    `tfsdk:"capture_har"`
+	HAROutputPath             types.String  `tfsdk:"har_output_path"`
+	HAR                       types.String  `tfsdk:"har"`
+	StatusCode                types.Int64   `tfsdk:"status_code"`
+}
+
+type expectedChecksumModel struct {
+	Algorithm types.String `tfsdk:"algorithm"`
+	Value     types.String `tfsdk:"value"`
 }
 
 type retryModel struct {
-	Attempts types.Int64 `tfsdk:"attempts"`
-	MinDelay types.Int64 `tfsdk:"min_delay_ms"`
-	MaxDelay types.Int64 `tfsdk:"max_delay_ms"`
+	Attempts           types.Int64  `tfsdk:"attempts"`
+	MinDelay           types.Int64  `tfsdk:"min_delay_ms"`
+	MaxDelay           types.Int64  `tfsdk:"max_delay_ms"`
+	MaxElapsedTime     types.Int64  `tfsdk:"max_elapsed_time_ms"`
+	Jitter             types.String `tfsdk:"jitter"`
+	RetryNonIdempotent types.Bool   `tfsdk:"retry_non_idempotent"`
+	On                 types.List   `tfsdk:"on"`
+	RetryIf            types.String `tfsdk:"retry_if"`
+	WhileBodyMatches   types.String `tfsdk:"while_body_matches"`
+	Until              types.Object `tfsdk:"until"`
+}
+
+type retryUntilModel struct {
+	JSONPath types.String `tfsdk:"jsonpath"`
+	Equals   types.String `tfsdk:"equals"`
+	Regex    types.String `tfsdk:"regex"`
+}
+
+// decodeBase64 decodes a base64 string in standard or URL-safe encoding,
+// with or without padding, ignoring whitespace.
+func decodeBase64(s string) ([]byte, error) {
+	s = strings.Join(strings.Fields(s), "")
+
+	encoding := base64.StdEncoding
+	if strings.ContainsAny(s, "-_") {
+		encoding = base64.URLEncoding
+	}
+
+	if !strings.HasSuffix(s, "=") {
+		encoding = encoding.WithPadding(base64.NoPadding)
+	}
+
+	return encoding.DecodeString(s)
+}
+
+// splitLines splits the string into lines, removing the line endings. A
+// trailing newline does not result in an empty last line.
+func splitLines(s string) []string {
+	lines := []string{}
+
+	if s == "" {
+		return lines
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(s, "\n"), "\n") {
+		lines = append(lines, strings.TrimSuffix(line, "\r"))
+	}
+
+	return lines
+}
+
+// isJSONDocument returns true if the given string is a JSON object or array.
+func isJSONDocument(s string) bool {
+	trimmed := strings.TrimSpace(s)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		return false
+	}
+
+	return json.Valid([]byte(trimmed))
+}
+
+// isJSONMediaType returns true for application/json and structured syntax
+// suffix media types such as application/problem+json.
+func isJSONMediaType(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
 }
 
 var _ retryablehttp.LeveledLogger = levelledLogger{}
@@ -4,23 +4,48 @@
 package provider
 
 import (
+	"bytes"
+	"compress/gzip"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
 	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"encoding/pem"
 	"fmt"
 	"io"
+	"math/big"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/http/httputil"
 	"net/url"
+	"os"
+	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/andybalholm/brotli"
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	coltracev1 "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracev1 "go.opentelemetry.io/proto/otlp/trace/v1"
+	"golang.org/x/crypto/ocsp"
+	"google.golang.org/protobuf/proto"
 )
 
 func TestDataSource_200(t *testing.T) {
@@ -49,6 +74,14 @@ func TestDataSource_200(t *testing.T) {
 					resource.TestCheckResourceAttr("data.http.http_test", "response_headers.Content-Type", "text/plain"),
 					resource.TestCheckResourceAttr("data.http.http_test", "response_headers.X-Single", "foobar"),
 					resource.TestCheckResourceAttr("data.http.http_test", "response_headers.X-Double", "1, 2"),
+					resource.TestCheckResourceAttr("data.http.http_test", "response_headers_all.X-Single.#", "1"),
+					resource.TestCheckResourceAttr("data.http.http_test", "response_headers_all.X-Single.0", "foobar"),
+					resource.TestCheckResourceAttr("data.http.http_test", "response_headers_all.X-Double.#", "2"),
+					resource.TestCheckResourceAttr("data.http.http_test", "response_headers_all.X-Double.0", "1"),
+					resource.TestCheckResourceAttr("data.http.http_test", "response_headers_all.X-Double.1", "2"),
+					resource.TestCheckResourceAttr("data.http.http_test", "response_headers_lowercase.content-type", "text/plain"),
+					resource.TestCheckResourceAttr("data.http.http_test", "response_headers_lowercase.x-double", "1, 2"),
+					resource.TestCheckNoResourceAttr("data.http.http_test", "response_headers_lowercase.Content-Type"),
 					resource.TestCheckResourceAttr("data.http.http_test", "status_code", "200"),
 				),
 			},
@@ -881,6 +914,7 @@ func TestDataSource_RequestBody(t *testing.T) {
 					}`, svr.URL),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("data.http.test", "status_code", "200"),
+					resource.TestCheckNoResourceAttr("data.http.test", "request_body_bytes_sent"),
 				),
 			},
 			{
@@ -891,6 +925,7 @@ func TestDataSource_RequestBody(t *testing.T) {
 					}`, svr.URL),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("data.http.test", "status_code", "200"),
+					resource.TestCheckResourceAttr("data.http.test", "request_body_bytes_sent", "4"),
 				),
 			},
 			{
@@ -901,6 +936,7 @@ func TestDataSource_RequestBody(t *testing.T) {
 					}`, svr.URL),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("data.http.test", "status_code", "400"),
+					resource.TestCheckResourceAttr("data.http.test", "request_body_bytes_sent", "8"),
 				),
 			},
 		},
@@ -959,12 +995,3794 @@ func TestDataSource_ResponseBodyBinary(t *testing.T) {
 					// Note the replacement character in the string representation in `response_body`.
 					resource.TestCheckResourceAttr("data.http.http_test", "response_body", "GIF89a\x01\x00\x01\x00�\x00\x00\x00\x00\x00\x00\x00\x00!�\x04\x01\x00\x00\x00\x00,\x00\x00\x00\x00\x01\x00\x01\x00\x00\x02\x02D\x01\x00;"),
 					resource.TestCheckResourceAttr("data.http.http_test", "response_body_base64", "R0lGODlhAQABAIAAAAAAAAAAACH5BAEAAAAALAAAAAABAAEAAAICRAEAOw=="),
+					resource.TestCheckResourceAttr("data.http.http_test", "response_body_sha256", "548f2d6f4d0d820c6c5ffbeffcbd7f0e73193e2932eefe542accc84762deec87"),
+					resource.TestCheckResourceAttr("data.http.http_test", "response_body_sha512", "b2ca25a3311dc42942e046eb1a27038b71d689925b7d6b3ebb4d7cd2c7b9a0c7de3d10175790ac060dc3f8acf3c1708c336626be06879097f4d0ecaa7f567041"),
+					resource.TestCheckResourceAttr("data.http.http_test", "response_body_md5", "df3e567d6f16d040326c7a0ea29a4f41"),
+					resource.TestCheckResourceAttr("data.http.http_test", "response_size_bytes", "43"),
 				),
 			},
 		},
 	})
 }
 
+func TestDataSource_ContentType(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Header.Get("Content-Type")))
+	}))
+	defer svr.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url          = "%s"
+								method       = "POST"
+								content_type = "application/json"
+								request_body = jsonencode({ "key" = "value" })
+							}`, svr.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.http_test", "response_body", "application/json"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url          = "%s"
+								method       = "POST"
+								content_type = "application/json"
+								request_body = jsonencode({ "key" = "value" })
+
+								request_headers = {
+									Content-Type = "application/vnd.api+json"
+								}
+							}`, svr.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.http_test", "response_body", "application/vnd.api+json"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_ContentTypeInvalid(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	}))
+	defer svr.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url          = "%s"
+								content_type = "application/json; charset"
+							}`, svr.URL),
+				ExpectError: regexp.MustCompile(`Invalid Content Type`),
+			},
+		},
+	})
+}
+
+func TestDataSource_SendContentLength(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		_, _ = fmt.Fprintf(w, "%d %s", r.ContentLength, strings.Join(r.TransferEncoding, ","))
+	}))
+	defer svr.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url          = "%s"
+								method       = "POST"
+								request_body = "test"
+							}`, svr.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.http_test", "response_body", "4 "),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url                 = "%s"
+								method              = "POST"
+								request_body        = "test"
+								send_content_length = true
+							}`, svr.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.http_test", "response_body", "4 "),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url                 = "%s"
+								method              = "POST"
+								request_body        = "test"
+								send_content_length = false
+							}`, svr.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.http_test", "response_body", "-1 chunked"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_ErrorCode(t *testing.T) {
+	closedSvr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	closedSvr.Close()
+
+	tlsSvr := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer tlsSvr.Close()
+
+	errorSvr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer errorSvr.Close()
+
+	timeoutSvr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(time.Duration(10) * time.Millisecond)
+	}))
+	defer timeoutSvr.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url = "https://%s.com"
+							}`, uuid.New().String()),
+				ExpectError: regexp.MustCompile(`Error code: dns_error`),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url = "%s"
+							}`, closedSvr.URL),
+				ExpectError: regexp.MustCompile(`Error code: connection_refused`),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url = "%s"
+							}`, tlsSvr.URL),
+				ExpectError: regexp.MustCompile(`Error code: tls_verify_failed`),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url = "%s"
+							}`, errorSvr.URL),
+				ExpectError: regexp.MustCompile(`giving up after 1 attempt\(s\)\n\nError code: http_status`),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url                = "%s"
+								request_timeout_ms = 5
+							}`, timeoutSvr.URL),
+				ExpectError: regexp.MustCompile(`Error code: timeout`),
+			},
+		},
+	})
+}
+
+func TestDataSource_ResponseBodyJSON(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/text" {
+			_, _ = w.Write([]byte(`not json`))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"items": [{"id": 12345678901234567890, "name": "first", "tags": null}, {"id": 2, "enabled": true}]}`))
+	}))
+	defer svr.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url = "%s"
+							}
+
+							output "first_id" {
+								value = data.http.http_test.response_body_json.items[0].id
+							}
+
+							output "first_tags_null" {
+								value = data.http.http_test.response_body_json.items[0].tags == null
+							}
+
+							output "second_enabled" {
+								value = data.http.http_test.response_body_json.items[1].enabled
+							}`, svr.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("first_id", "12345678901234567890"),
+					resource.TestCheckOutput("first_tags_null", "true"),
+					resource.TestCheckOutput("second_enabled", "true"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url = "%s/text"
+							}
+
+							output "json_null" {
+								value = data.http.http_test.response_body_json == null
+							}`, svr.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.http_test", "response_body", "not json"),
+					resource.TestCheckOutput("json_null", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_ResponseJSONPath(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/text" {
+			_, _ = w.Write([]byte(`not json`))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data": {"items": [{"id": 1, "name": "first"}, {"id": 2, "name": "second"}], "meta": {"total": 2}}}`))
+	}))
+	defer svr.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url = "%s"
+
+								response_jsonpath = {
+									first_name = "$.data.items[0].name"
+									total      = "$.data.meta.total"
+									meta       = "$.data.meta"
+									ids        = "$.data.items[*].id"
+									missing    = "$.data.missing"
+								}
+							}`, svr.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.http_test", "extracted.%", "4"),
+					resource.TestCheckResourceAttr("data.http.http_test", "extracted.first_name", "first"),
+					resource.TestCheckResourceAttr("data.http.http_test", "extracted.total", "2"),
+					resource.TestCheckResourceAttr("data.http.http_test", "extracted.meta", `{"total":2}`),
+					resource.TestCheckResourceAttr("data.http.http_test", "extracted.ids", `[1,2]`),
+					resource.TestCheckNoResourceAttr("data.http.http_test", "extracted.missing"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url = "%s/text"
+
+								response_jsonpath = {
+									name = "$.name"
+								}
+							}`, svr.URL),
+				ExpectError: regexp.MustCompile(`The response body could not be parsed as JSON`),
+			},
+		},
+	})
+}
+
+func TestDataSource_ResponseJSONPathInvalid(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer svr.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url = "%s"
+
+								response_jsonpath = {
+									name = "$.items[?(@.id =="
+								}
+							}`, svr.URL),
+				ExpectError: regexp.MustCompile(`Invalid JSONPath Expression`),
+			},
+		},
+	})
+}
+
+func TestDataSource_ErrorDetailCompact(t *testing.T) {
+	closedSvr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	closedSvr.Close()
+
+	errorSvr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer errorSvr.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url          = "%s"
+								error_detail = "compact"
+							}`, closedSvr.URL),
+				ExpectError: regexp.MustCompile(
+					fmt.Sprintf(`GET %s failed\s+after\s+1\s+attempt\(s\):\s+dial\s+tcp\s+\S+:\s+connect:\s+connection\s+refused\s+\[error_code:\s+connection_refused\]`,
+						regexp.QuoteMeta(closedSvr.URL)),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url          = "%s"
+								error_detail = "compact"
+
+								retry {
+									attempts     = 1
+									max_delay_ms = 10
+								}
+							}`, errorSvr.URL),
+				ExpectError: regexp.MustCompile(
+					fmt.Sprintf(`GET %s failed\s+after\s+2\s+attempt\(s\):\s+unexpected\s+status\s+code\s+503\s+\[error_code:\s+http_status\]`,
+						regexp.QuoteMeta(errorSvr.URL)),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_ErrorDetailInvalid(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+							data "http" "http_test" {
+								url          = "https://example.com"
+								error_detail = "verbose"
+							}`,
+				ExpectError: regexp.MustCompile(`value must be one of: \["full" "compact"\]`),
+			},
+		},
+	})
+}
+
+func TestDataSource_NextCursor(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			_, _ = w.Write([]byte(`{"items": [1, 2], "meta": {"next": "abc123"}}`))
+		case "abc123":
+			_, _ = w.Write([]byte(`{"items": [3, 4], "meta": {"next": ""}}`))
+		default:
+			_, _ = w.Write([]byte(`{"items": [], "meta": {"next": null}}`))
+		}
+	}))
+	defer svr.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url                  = "%s"
+								next_cursor_jsonpath = "$.meta.next"
+							}`, svr.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.http_test", "next_cursor", "abc123"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url                  = "%s?cursor=abc123"
+								next_cursor_jsonpath = "$.meta.next"
+							}`, svr.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("data.http.http_test", "next_cursor"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url                  = "%s?cursor=last"
+								next_cursor_jsonpath = "$.meta.next"
+							}`, svr.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("data.http.http_test", "next_cursor"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_ResponseHeadersAll(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Set-Cookie", "a=1; Expires=Wed, 21 Oct 2015 07:28:00 GMT")
+		w.Header().Add("Set-Cookie", "b=2")
+	}))
+	defer svr.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url = "%s"
+							}`, svr.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.http_test", "response_headers.Set-Cookie", "a=1; Expires=Wed, 21 Oct 2015 07:28:00 GMT, b=2"),
+					resource.TestCheckResourceAttr("data.http.http_test", "response_headers_all.Set-Cookie.#", "2"),
+					resource.TestCheckResourceAttr("data.http.http_test", "response_headers_all.Set-Cookie.0", "a=1; Expires=Wed, 21 Oct 2015 07:28:00 GMT"),
+					resource.TestCheckResourceAttr("data.http.http_test", "response_headers_all.Set-Cookie.1", "b=2"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_PreflightTLSOnly(t *testing.T) {
+	var serverRequests int
+
+	testServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serverRequests++
+	}))
+	defer testServer.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url         = "%s"
+								preflight   = "tls_only"
+								ca_cert_pem = <<EOT
+%s
+EOT
+							}`, testServer.URL, certToPEM(testServer.Certificate())),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.http_test", "tls_handshake.negotiated_protocol", "http/1.1"),
+					resource.TestCheckResourceAttr("data.http.http_test", "tls_handshake.version", "TLS 1.3"),
+					resource.TestCheckResourceAttr("data.http.http_test", "tls_handshake.cipher_suite", "TLS_AES_128_GCM_SHA256"),
+					resource.TestCheckResourceAttr("data.http.http_test", "tls_handshake.peer_certificate_subject", "O=Acme Co"),
+					resource.TestCheckResourceAttrSet("data.http.http_test", "tls_handshake.peer_certificate_not_after"),
+					resource.TestCheckNoResourceAttr("data.http.http_test", "status_code"),
+					func(_ *terraform.State) error {
+						if serverRequests != 0 {
+							return fmt.Errorf("expected no HTTP requests, got %d", serverRequests)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url       = "%s"
+								preflight = "tls_only"
+							}`, testServer.URL),
+				ExpectError: regexp.MustCompile(`Error code: tls_verify_failed`),
+			},
+			{
+				Config: `
+							data "http" "http_test" {
+								url       = "http://127.0.0.1"
+								preflight = "tls_only"
+							}`,
+				ExpectError: regexp.MustCompile(`TLS preflight requires an https URL`),
+			},
+		},
+	})
+}
+
+func TestDataSource_InsecureEnvironmentVariable(t *testing.T) {
+	testServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+	}))
+	defer testServer.Close()
+
+	t.Setenv("TF_HTTP_INSECURE", "true")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+  								url = "%s"
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.http_test", "status_code", "200"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+  								url = "%s"
+
+  								insecure = false
+							}`, testServer.URL),
+				ExpectError: regexp.MustCompile(`Error making request`),
+			},
+		},
+	})
+}
+
+func TestDataSource_InvalidInsecureEnvironmentVariable(t *testing.T) {
+	t.Setenv("TF_HTTP_INSECURE", "maybe")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+							data "http" "http_test" {
+  								url = "https://example.com"
+							}`,
+				ExpectError: regexp.MustCompile(`The TF_HTTP_INSECURE environment variable must be a boolean`),
+			},
+		},
+	})
+}
+
+func TestDataSource_TimeoutEnvironmentVariable(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(time.Duration(10) * time.Millisecond)
+	}))
+	defer svr.Close()
+
+	t.Setenv("TF_HTTP_REQUEST_TIMEOUT_MS", "5")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+  								url = "%s"
+							}`, svr.URL),
+				ExpectError: regexp.MustCompile(`request exceeded the specified timeout: 5ms`),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+  								url = "%s"
+								request_timeout_ms = 1000
+							}`, svr.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.http_test", "status_code", "200"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_TimeoutAliasEnvironmentVariable(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(time.Duration(10) * time.Millisecond)
+	}))
+	defer svr.Close()
+
+	t.Setenv("TF_HTTP_TIMEOUT_MS", "5")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+  								url = "%s"
+							}`, svr.URL),
+				ExpectError: regexp.MustCompile(`request exceeded the specified timeout: 5ms`),
+			},
+		},
+	})
+}
+
+func TestDataSource_CABundleEnvironmentVariable(t *testing.T) {
+	testServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer testServer.Close()
+
+	caBundle := filepath.Join(t.TempDir(), "ca-bundle.pem")
+	if err := os.WriteFile(caBundle, []byte(certToPEM(testServer.Certificate())), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("TF_HTTP_CA_BUNDLE", caBundle)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+  								url = "%s"
+							}`, testServer.URL),
+				Check: resource.TestCheckResourceAttr("data.http.http_test", "status_code", "200"),
+			},
+		},
+	})
+}
+
+func TestDataSource_DefaultHeadersEnvironmentVariable(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte(r.Header.Get("X-Team") + "," + r.Header.Get("X-Runner")))
+	}))
+	defer testServer.Close()
+
+	t.Setenv("TF_HTTP_DEFAULT_HEADERS", `{"X-Team": "platform", "X-Runner": "shared"}`)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+  								url = "%s"
+							}`, testServer.URL),
+				Check: resource.TestCheckResourceAttr("data.http.http_test", "response_body", "platform,shared"),
+			},
+			{
+				Config: fmt.Sprintf(`
+							provider "http" {
+								host {
+									name = "127.0.0.1"
+									request_headers = {
+										X-Runner = "dedicated"
+									}
+								}
+							}
+
+							data "http" "http_test" {
+  								url = "%s"
+								request_headers = {
+									x-team = "data"
+								}
+							}`, testServer.URL),
+				Check: resource.TestCheckResourceAttr("data.http.http_test", "response_body", "data,dedicated"),
+			},
+		},
+	})
+}
+
+func TestDataSource_DefaultHeadersEnvironmentVariable_Invalid(t *testing.T) {
+	t.Setenv("TF_HTTP_DEFAULT_HEADERS", "X-Team=platform")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+							data "http" "http_test" {
+  								url = "https://example.com"
+							}`,
+				ExpectError: regexp.MustCompile(`The TF_HTTP_DEFAULT_HEADERS environment variable must be a JSON object`),
+			},
+		},
+	})
+}
+
+func TestDataSource_ResponseStreamJSONPath(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"name": "a", "status": "active"}, {"name": "b", "status": "retired"}, {"name": "c", "status": "active"}]`))
+	}))
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url                      = "%s"
+								response_stream_jsonpath = "$[?(@.status == 'active')].name"
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.http_test", "streamed_elements.#", "2"),
+					resource.TestCheckResourceAttr("data.http.http_test", "streamed_elements.0", "a"),
+					resource.TestCheckResourceAttr("data.http.http_test", "streamed_elements.1", "c"),
+					resource.TestCheckNoResourceAttr("data.http.http_test", "response_body"),
+					resource.TestCheckResourceAttrSet("data.http.http_test", "response_body_sha256"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url                      = "%s"
+								response_stream_jsonpath = "$[?(@.status == 'retired')]"
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.http_test", "streamed_elements.#", "1"),
+					resource.TestCheckResourceAttr("data.http.http_test", "streamed_elements.0", `{"name":"b","status":"retired"}`),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_ResponseStreamJSONPath_NotArray(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"items": []}`))
+	}))
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url                      = "%s"
+								response_stream_jsonpath = "$[*]"
+							}`, testServer.URL),
+				ExpectError: regexp.MustCompile(`the response body is not a\s+JSON\s+array`),
+			},
+		},
+	})
+}
+
+func TestDataSource_ExpectedChecksum(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("1.0.0"))
+	}))
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url = "%s"
+
+								expected_checksum {
+									algorithm = "md5"
+									value     = "d41d8cd98f00b204e9800998ecf8427e"
+								}
+							}`, testServer.URL),
+				ExpectError: regexp.MustCompile(`Response Checksum Mismatch`),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url = "%s"
+
+								expected_checksum {
+									algorithm = "sha256"
+								}
+							}`, testServer.URL),
+				ExpectError: regexp.MustCompile(`Attribute "expected_checksum.value" must be specified`),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url = "%s"
+
+								expected_checksum {
+									algorithm = "sha256"
+									value     = "92521FC3CBD964BDC9F584A991B89FDDAA5754ED1CC96D6D42445338669C1305"
+								}
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.http_test", "response_body", "1.0.0"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_SanitizeBody(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("a\xffb"))
+	}))
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url           = "%s"
+								sanitize_body = "error"
+							}`, testServer.URL),
+				ExpectError: regexp.MustCompile(`Response body is not valid UTF-8`),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url           = "%s"
+								sanitize_body = "replace"
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.http_test", "response_body", "a�b"),
+					resource.TestCheckResourceAttr("data.http.http_test", "response_body_base64", "Yf9i"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url           = "%s"
+								sanitize_body = "strip"
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.http_test", "response_body", "ab"),
+					resource.TestCheckResourceAttr("data.http.http_test", "body", "ab"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_ResponseRegex(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<a href="/download/v1.4.2/tool.zip">Download v1.4.2</a>`))
+	}))
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url            = "%s"
+								response_regex = "href=\"(?P<path>[^\"]+)\">Download v(?P<version>[0-9.]+)(?P<suffix>-rc)?"
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.http_test", "response_regex_matches.%", "2"),
+					resource.TestCheckResourceAttr("data.http.http_test", "response_regex_matches.path", "/download/v1.4.2/tool.zip"),
+					resource.TestCheckResourceAttr("data.http.http_test", "response_regex_matches.version", "1.4.2"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url            = "%s"
+								response_regex = "Release (?P<version>[0-9.]+)"
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.http_test", "response_regex_matches.%", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_ResponseRegex_NoNamedGroups(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+							data "http" "http_test" {
+								url            = "http://localhost"
+								response_regex = "v([0-9.]+)"
+							}`,
+				ExpectError: regexp.MustCompile(`must contain at least one named capture group`),
+			},
+		},
+	})
+}
+
+func TestDataSource_SplitDocuments(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/yaml":
+			w.Header().Set("Content-Type", "application/yaml")
+			_, _ = w.Write([]byte("---\nkind: Namespace\n--- # service\nkind: Service\n---\n\n---\nkind: Deployment\n"))
+		case "/json":
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			_, _ = w.Write([]byte("{\"id\": 1}\n{\"id\": 2}\n[3]"))
+		}
+	}))
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "yaml" {
+								url             = "%[1]s/yaml"
+								split_documents = "yaml"
+							}
+
+							data "http" "json" {
+								url             = "%[1]s/json"
+								split_documents = "json"
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.yaml", "documents.#", "3"),
+					resource.TestCheckResourceAttr("data.http.yaml", "documents.0", "kind: Namespace\n"),
+					resource.TestCheckResourceAttr("data.http.yaml", "documents.1", "kind: Service\n"),
+					resource.TestCheckResourceAttr("data.http.yaml", "documents.2", "kind: Deployment\n"),
+					resource.TestCheckResourceAttr("data.http.json", "documents.#", "3"),
+					resource.TestCheckResourceAttr("data.http.json", "documents.0", `{"id": 1}`),
+					resource.TestCheckResourceAttr("data.http.json", "documents.1", `{"id": 2}`),
+					resource.TestCheckResourceAttr("data.http.json", "documents.2", `[3]`),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_SkipResponseBody(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Header().Set("X-Health", "ok")
+		_, _ = w.Write([]byte(strings.Repeat("x", 1<<20)))
+	}))
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url                = "%s"
+								skip_response_body = true
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.http_test", "status_code", "200"),
+					resource.TestCheckResourceAttr("data.http.http_test", "response_headers.X-Health", "ok"),
+					resource.TestCheckNoResourceAttr("data.http.http_test", "response_body"),
+					resource.TestCheckNoResourceAttr("data.http.http_test", "response_body_base64"),
+					resource.TestCheckNoResourceAttr("data.http.http_test", "response_body_sha256"),
+					resource.TestCheckNoResourceAttr("data.http.http_test", "response_size_bytes"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_ProviderStrict(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("1.0.0"))
+	}))
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							provider "http" {
+								strict = true
+							}
+
+							data "http" "http_test" {
+								url = "%s"
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.http_test", "response_body", "1.0.0"),
+					resource.TestCheckNoResourceAttr("data.http.http_test", "body"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_DecodeContentEncoding(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+
+		switch r.Header.Get("Accept-Encoding") {
+		case "gzip":
+			w.Header().Set("Content-Encoding", "gzip")
+			gzipWriter := gzip.NewWriter(w)
+			_, _ = gzipWriter.Write([]byte("compressed"))
+			_ = gzipWriter.Close()
+		case "br":
+			w.Header().Set("Content-Encoding", "br")
+			brotliWriter := brotli.NewWriter(w)
+			_, _ = brotliWriter.Write([]byte("compressed"))
+			_ = brotliWriter.Close()
+		case "zstd":
+			w.Header().Set("Content-Encoding", "zstd")
+			_, _ = w.Write([]byte("compressed"))
+		}
+	}))
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "gzip" {
+								url             = "%[1]s"
+								request_headers = {
+									Accept-Encoding = "gzip"
+								}
+							}
+
+							data "http" "br" {
+								url             = "%[1]s"
+								request_headers = {
+									Accept-Encoding = "br"
+								}
+							}
+
+							data "http" "raw" {
+								url                     = "%[1]s"
+								decode_content_encoding = false
+								request_headers = {
+									Accept-Encoding = "gzip"
+								}
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.gzip", "response_body", "compressed"),
+					resource.TestCheckResourceAttr("data.http.gzip", "response_size_bytes", "10"),
+					resource.TestCheckResourceAttr("data.http.gzip", "response_headers.Content-Encoding", "gzip"),
+					resource.TestCheckResourceAttr("data.http.br", "response_body", "compressed"),
+					resource.TestMatchResourceAttr("data.http.raw", "response_body_base64", regexp.MustCompile(`^H4sI`)),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "http" "zstd" {
+								url             = "%s"
+								request_headers = {
+									Accept-Encoding = "zstd"
+								}
+							}`, testServer.URL),
+				ExpectError: regexp.MustCompile(`unsupported content encoding "zstd"`),
+			},
+		},
+	})
+}
+
+// TestDataSource_ResponseBodyChecksums_ContentEncoding verifies that the
+// checksums are computed from the response body after Content-Encoding
+// decoding, or from the encoded body if decoding is disabled.
+func TestDataSource_ResponseBodyChecksums_ContentEncoding(t *testing.T) {
+	var encoded bytes.Buffer
+
+	gzipWriter := gzip.NewWriter(&encoded)
+	_, _ = gzipWriter.Write([]byte("compressed"))
+	_ = gzipWriter.Close()
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Header().Set("Content-Encoding", "gzip")
+		_, _ = w.Write(encoded.Bytes())
+	}))
+	defer testServer.Close()
+
+	decodedSHA256 := sha256.Sum256([]byte("compressed"))
+	encodedSHA256 := sha256.Sum256(encoded.Bytes())
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "decoded" {
+								url             = "%[1]s"
+								request_headers = {
+									Accept-Encoding = "gzip"
+								}
+							}
+
+							data "http" "encoded" {
+								url                     = "%[1]s"
+								decode_content_encoding = false
+								request_headers = {
+									Accept-Encoding = "gzip"
+								}
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.decoded", "response_body_sha256", hex.EncodeToString(decodedSHA256[:])),
+					resource.TestCheckResourceAttr("data.http.encoded", "response_body_sha256", hex.EncodeToString(encodedSHA256[:])),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_Timing(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("1.0.0"))
+	}))
+	defer testServer.Close()
+
+	atLeast := func(minimum int64) resource.CheckResourceAttrWithFunc {
+		return func(value string) error {
+			v, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return err
+			}
+
+			if v < minimum {
+				return fmt.Errorf("expected at least %d, got %d", minimum, v)
+			}
+
+			return nil
+		}
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url = "%s"
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrWith("data.http.http_test", "timing.time_to_first_byte_ms", atLeast(50)),
+					resource.TestCheckResourceAttrWith("data.http.http_test", "timing.total_ms", atLeast(50)),
+					resource.TestCheckResourceAttr("data.http.http_test", "timing.tls_ms", "0"),
+					resource.TestCheckResourceAttrSet("data.http.http_test", "timing.dns_ms"),
+					resource.TestCheckResourceAttrSet("data.http.http_test", "timing.connect_ms"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_TLSVersionAndHTTPProtocol(t *testing.T) {
+	testServer := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("1.0.0"))
+	}))
+	testServer.EnableHTTP2 = true
+	testServer.StartTLS()
+	defer testServer.Close()
+
+	plainServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+	}))
+	defer plainServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "tls" {
+								url      = "%s"
+								insecure = true
+							}
+
+							data "http" "plain" {
+								url = "%s"
+							}`, testServer.URL, plainServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.tls", "tls_version", "TLS 1.3"),
+					resource.TestCheckResourceAttrSet("data.http.tls", "tls_cipher_suite"),
+					resource.TestCheckResourceAttr("data.http.tls", "http_protocol", "HTTP/2.0"),
+					resource.TestCheckNoResourceAttr("data.http.plain", "tls_version"),
+					resource.TestCheckNoResourceAttr("data.http.plain", "tls_cipher_suite"),
+					resource.TestCheckResourceAttr("data.http.plain", "http_protocol", "HTTP/1.1"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_Links(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Add("Link", `<https://api.example.com/items?page=3&fields=a,b>; rel="next", </items?page=10>; rel="last"`)
+		w.Header().Add("Link", `</items?page=1>; rel="prev first"`)
+		_, _ = w.Write([]byte("[]"))
+	}))
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url = "%s/items?page=2"
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.http_test", "links.%", "4"),
+					resource.TestCheckResourceAttr("data.http.http_test", "links.next", "https://api.example.com/items?page=3&fields=a,b"),
+					resource.TestCheckResourceAttr("data.http.http_test", "links.last", testServer.URL+"/items?page=10"),
+					resource.TestCheckResourceAttr("data.http.http_test", "links.prev", testServer.URL+"/items?page=1"),
+					resource.TestCheckResourceAttr("data.http.http_test", "links.first", testServer.URL+"/items?page=1"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_Paginate(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 0 {
+			page = 1
+		}
+
+		switch r.URL.Path {
+		case "/link":
+			if page < 3 {
+				w.Header().Set("Link", fmt.Sprintf(`</link?page=%d>; rel="next"`, page+1))
+			}
+			_, _ = w.Write([]byte(fmt.Sprintf(`[%d]`, page)))
+		case "/param":
+			if page > 2 {
+				_, _ = w.Write([]byte(`[]`))
+				return
+			}
+			_, _ = w.Write([]byte(fmt.Sprintf(`[%d, %d]`, 2*page-1, 2*page)))
+		case "/cursor":
+			next := `null`
+			if page < 2 {
+				next = fmt.Sprintf(`"/cursor?page=%d"`, page+1)
+			}
+			_, _ = w.Write([]byte(fmt.Sprintf(`{"items": [{"id": %d}], "next": %s}`, page, next)))
+		}
+	}))
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "link" {
+								url = "%[1]s/link"
+
+								paginate {
+									mode = "link_header"
+								}
+							}
+
+							data "http" "link_max_pages" {
+								url = "%[1]s/link"
+
+								paginate {
+									mode      = "link_header"
+									max_pages = 2
+								}
+							}
+
+							data "http" "param" {
+								url = "%[1]s/param"
+
+								paginate {
+									mode = "page_param"
+								}
+							}
+
+							data "http" "cursor" {
+								url = "%[1]s/cursor"
+
+								paginate {
+									mode           = "next_jsonpath"
+									next_jsonpath  = "$.next"
+									items_jsonpath = "$.items"
+								}
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.link", "response_body", "[1]"),
+					resource.TestCheckResourceAttr("data.http.link", "pages.#", "3"),
+					resource.TestCheckResourceAttr("data.http.link", "pages.2", "[3]"),
+					resource.TestCheckResourceAttr("data.http.link", "items", "[1,2,3]"),
+					resource.TestCheckResourceAttr("data.http.link_max_pages", "pages.#", "2"),
+					resource.TestCheckResourceAttr("data.http.link_max_pages", "items", "[1,2]"),
+					resource.TestCheckResourceAttr("data.http.param", "pages.#", "3"),
+					resource.TestCheckResourceAttr("data.http.param", "items", "[1,2,3,4]"),
+					resource.TestCheckResourceAttr("data.http.cursor", "pages.#", "2"),
+					resource.TestCheckResourceAttr("data.http.cursor", "items", `[{"id":1},{"id":2}]`),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_Paginate_MissingNextJSONPath(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+							data "http" "http_test" {
+								url = "http://localhost"
+
+								paginate {
+									mode = "next_jsonpath"
+								}
+							}`,
+				ExpectError: regexp.MustCompile(`The next_jsonpath attribute must be configured`),
+			},
+		},
+	})
+}
+
+func TestDataSource_RetryAfter(t *testing.T) {
+	var requests atomic.Int32
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("1.0.0"))
+	}))
+	defer testServer.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url = "%s"
+
+								retry {
+									attempts     = 1
+									max_delay_ms = 100
+								}
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.http_test", "status_code", "200"),
+					resource.TestCheckResourceAttr("data.http.http_test", "retry_after_honored", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_HAR(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("1.0.0"))
+	}))
+	defer testServer.Close()
+
+	harPath := filepath.Join(t.TempDir(), "exchange.har")
+
+	checkHAR := func(value string) error {
+		var document harDocument
+
+		if err := json.Unmarshal([]byte(value), &document); err != nil {
+			return err
+		}
+
+		if document.Log.Version != "1.2" || len(document.Log.Entries) != 1 {
+			return fmt.Errorf("unexpected HAR document: %s", value)
+		}
+
+		entry := document.Log.Entries[0]
+
+		if entry.Request.Method != "POST" || entry.Request.PostData == nil || entry.Request.PostData.Text != "ping" {
+			return fmt.Errorf("unexpected HAR request: %+v", entry.Request)
+		}
+
+		if entry.Response.Status != 200 || entry.Response.Content.Text != "1.0.0" {
+			return fmt.Errorf("unexpected HAR response: %+v", entry.Response)
+		}
+
+		return nil
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url             = "%s"
+								method          = "POST"
+								request_body    = "ping"
+								capture_har     = true
+								har_output_path = %q
+							}`, testServer.URL, harPath),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrWith("data.http.http_test", "har", checkHAR),
+					func(_ *terraform.State) error {
+						har, err := os.ReadFile(harPath)
+						if err != nil {
+							return err
+						}
+
+						return checkHAR(string(har))
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_ResponseBodyLines(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("abc123  file-a.zip\r\ndef456  file-b.zip\n\nghi789  file-c.zip\n"))
+	}))
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url = "%s"
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.http_test", "response_body_lines.#", "4"),
+					resource.TestCheckResourceAttr("data.http.http_test", "response_body_lines.0", "abc123  file-a.zip"),
+					resource.TestCheckResourceAttr("data.http.http_test", "response_body_lines.1", "def456  file-b.zip"),
+					resource.TestCheckResourceAttr("data.http.http_test", "response_body_lines.2", ""),
+					resource.TestCheckResourceAttr("data.http.http_test", "response_body_lines.3", "ghi789  file-c.zip"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_ResponseTrailers(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Header().Set("Trailer", "X-Checksum, X-Status")
+		_, _ = w.Write([]byte("1.0.0"))
+		w.Header().Set("X-Checksum", "abc123")
+	}))
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url = "%s"
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.http_test", "response_trailers.%", "1"),
+					resource.TestCheckResourceAttr("data.http.http_test", "response_trailers.X-Checksum", "abc123"),
+					resource.TestCheckNoResourceAttr("data.http.http_test", "response_headers.X-Checksum"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_ResponseContentType(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", `Multipart/Mixed; Boundary="frontier"; charset=UTF-8`)
+	}))
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url = "%s"
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.http_test", "response_content_type", "multipart/mixed"),
+					resource.TestCheckResourceAttr("data.http.http_test", "response_content_type_params.%", "2"),
+					resource.TestCheckResourceAttr("data.http.http_test", "response_content_type_params.boundary", "frontier"),
+					resource.TestCheckResourceAttr("data.http.http_test", "response_content_type_params.charset", "UTF-8"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_DecodeResponseBase64(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+
+		switch r.URL.Path {
+		case "/padded":
+			_, _ = w.Write([]byte("c2VjcmV0LXZh\nbHVlLTE=\n"))
+		case "/url":
+			_, _ = w.Write([]byte("Pz8-Pw"))
+		default:
+			_, _ = w.Write([]byte("not base64!"))
+		}
+	}))
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "invalid" {
+								url                    = "%s/invalid"
+								decode_response_base64 = true
+							}`, testServer.URL),
+				ExpectError: regexp.MustCompile(`could\s+not\s+be\s+decoded\s+as\s+base64`),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "http" "padded" {
+								url                    = "%[1]s/padded"
+								decode_response_base64 = true
+							}
+
+							data "http" "url" {
+								url                    = "%[1]s/url"
+								decode_response_base64 = true
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.padded", "response_body", "secret-value-1"),
+					resource.TestCheckResourceAttr("data.http.url", "response_body", "??>?"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_Problem(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/problem":
+			w.Header().Set("Content-Type", "application/problem+json; charset=utf-8")
+			_, _ = w.Write([]byte(`{"type":"https://example.com/probs/out-of-credit","title":"You do not have enough credit.","status":403,"detail":"Your current balance is 30, but that costs 50.","instance":"/account/12345/msgs/abc","balance":30}`))
+		case "/minimal":
+			w.Header().Set("Content-Type", "application/problem+json")
+			_, _ = w.Write([]byte(`{"title":"Not Found"}`))
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"title":"Not a problem"}`))
+		}
+	}))
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "problem" {
+								url = "%[1]s/problem"
+							}
+
+							data "http" "minimal" {
+								url = "%[1]s/minimal"
+							}
+
+							data "http" "json" {
+								url = "%[1]s/json"
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.problem", "problem.type", "https://example.com/probs/out-of-credit"),
+					resource.TestCheckResourceAttr("data.http.problem", "problem.title", "You do not have enough credit."),
+					resource.TestCheckResourceAttr("data.http.problem", "problem.status", "403"),
+					resource.TestCheckResourceAttr("data.http.problem", "problem.detail", "Your current balance is 30, but that costs 50."),
+					resource.TestCheckResourceAttr("data.http.problem", "problem.instance", "/account/12345/msgs/abc"),
+					resource.TestCheckResourceAttr("data.http.minimal", "problem.type", "about:blank"),
+					resource.TestCheckResourceAttr("data.http.minimal", "problem.title", "Not Found"),
+					resource.TestCheckNoResourceAttr("data.http.minimal", "problem.status"),
+					resource.TestCheckNoResourceAttr("data.http.json", "problem.%"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_SuccessStatusCodes(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/created":
+			w.WriteHeader(http.StatusCreated)
+		case "/problem":
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"title":"You do not have enough credit.","status":403,"detail":"Your current balance is 30, but that costs 50."}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url                  = "%s/missing"
+								success_status_codes = [200, 201]
+							}`, testServer.URL),
+				ExpectError: regexp.MustCompile(`has\s+the\s+status\s+"404\s+Not\s+Found"`),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url                  = "%s/problem"
+								success_status_codes = [200]
+							}`, testServer.URL),
+				ExpectError: regexp.MustCompile(`Detail:\s+Your\s+current\s+balance\s+is\s+30`),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url                  = "%s/created"
+								success_status_codes = [200, 201]
+							}`, testServer.URL),
+				Check: resource.TestCheckResourceAttr("data.http.http_test", "status_code", "201"),
+			},
+		},
+	})
+}
+
+func TestDataSource_SuccessStatusCodes_Invalid(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+							data "http" "http_test" {
+								url                  = "http://localhost"
+								success_status_codes = [200, 42]
+							}`,
+				ExpectError: regexp.MustCompile(`value\s+must\s+be\s+between\s+100\s+and\s+599`),
+			},
+		},
+	})
+}
+
+func TestDataSource_RetryWhileBodyMatches(t *testing.T) {
+	var requests atomic.Int32
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Path == "/provisioning" || requests.Add(1) <= 2 {
+			_, _ = w.Write([]byte(`{"status": "provisioning"}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"status": "ready"}`))
+	}))
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url = "%s/provisioning"
+
+								retry {
+									attempts           = 1
+									max_delay_ms       = 10
+									while_body_matches = "\"status\":\\s*\"provisioning\""
+								}
+							}`, testServer.URL),
+				ExpectError: regexp.MustCompile(`giving\s+up\s+after\s+2\s+attempt\(s\):\s+the\s+response\s+body\s+still\s+matches`),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url = "%s"
+
+								retry {
+									attempts           = 3
+									max_delay_ms       = 10
+									while_body_matches = "\"status\":\\s*\"provisioning\""
+								}
+							}`, testServer.URL),
+				Check: resource.TestCheckResourceAttr("data.http.http_test", "response_body", `{"status": "ready"}`),
+			},
+		},
+	})
+}
+
+func TestDataSource_RetryWhileBodyMatches_Invalid(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+							data "http" "http_test" {
+								url = "http://localhost"
+
+								retry {
+									while_body_matches = "("
+								}
+							}`,
+				ExpectError: regexp.MustCompile(`The\s+regular\s+expression\s+could\s+not\s+be\s+compiled`),
+			},
+		},
+	})
+}
+
+func TestDataSource_RetryUntil(t *testing.T) {
+	var requests atomic.Int32
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Path == "/pending" || requests.Add(1) <= 2 {
+			_, _ = w.Write([]byte(`{"status": "PENDING"}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"status": "ACTIVE"}`))
+	}))
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url = "%s/pending"
+
+								retry {
+									attempts     = 1
+									max_delay_ms = 10
+
+									until {
+										jsonpath = "$.status"
+										equals   = "ACTIVE"
+									}
+								}
+							}`, testServer.URL),
+				ExpectError: regexp.MustCompile(`the\s+JSONPath\s+expression\s+"\$\.status"\s+is\s+"PENDING",\s+not\s+"ACTIVE"`),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url = "%s"
+
+								retry {
+									attempts     = 3
+									max_delay_ms = 10
+
+									until {
+										jsonpath = "$.status"
+										regex    = "^(ACTIVE|FAILED)$"
+									}
+								}
+							}`, testServer.URL),
+				Check: resource.TestCheckResourceAttr("data.http.http_test", "response_body", `{"status": "ACTIVE"}`),
+			},
+		},
+	})
+}
+
+func TestDataSource_RetryUntil_Invalid(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+							data "http" "http_test" {
+								url = "http://localhost"
+
+								retry {
+									until {
+										jsonpath = "$.status"
+									}
+								}
+							}`,
+				ExpectError: regexp.MustCompile(`Either\s+the\s+equals\s+or\s+the\s+regex\s+attribute\s+must\s+be\s+configured`),
+			},
+			{
+				Config: `
+							data "http" "http_test" {
+								url = "http://localhost"
+
+								retry {
+									until {
+										equals = "ACTIVE"
+									}
+								}
+							}`,
+				ExpectError: regexp.MustCompile(`Attribute\s+"retry.until.jsonpath"\s+must\s+be\s+specified`),
+			},
+			{
+				Config: `
+							data "http" "http_test" {
+								url = "http://localhost"
+
+								retry {
+									until {
+										jsonpath = "$.status"
+										equals   = "ACTIVE"
+										regex    = "ACTIVE"
+									}
+								}
+							}`,
+				ExpectError: regexp.MustCompile(`Invalid\s+Attribute\s+Combination`),
+			},
+		},
+	})
+}
+
+func TestDataSource_RetryMaxElapsedTime(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/unavailable" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		_, _ = w.Write([]byte("1.0.0"))
+	}))
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url = "%s/unavailable"
+
+								retry {
+									attempts            = 1000
+									min_delay_ms        = 50
+									max_delay_ms        = 50
+									max_elapsed_time_ms = 300
+								}
+							}`, testServer.URL),
+				ExpectError: regexp.MustCompile(`retry\s+deadline\s+of\s+300ms\s+exceeded\s+after\s+[0-9.]+m?s`),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url = "%s"
+
+								retry {
+									attempts            = 1000
+									max_elapsed_time_ms = 5000
+								}
+							}`, testServer.URL),
+				Check: resource.TestCheckResourceAttr("data.http.http_test", "response_body", "1.0.0"),
+			},
+		},
+	})
+}
+
+func TestDataSource_RetryJitter(t *testing.T) {
+	var requests atomic.Int32
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1)%2 == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		_, _ = w.Write([]byte("1.0.0"))
+	}))
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url = "%s"
+
+								retry {
+									attempts     = 1
+									min_delay_ms = 10
+									max_delay_ms = 20
+									jitter       = "full"
+								}
+							}`, testServer.URL),
+				Check: resource.TestCheckResourceAttr("data.http.http_test", "response_body", "1.0.0"),
+			},
+		},
+	})
+}
+
+func TestDataSource_RetryJitter_Invalid(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+							data "http" "http_test" {
+								url = "http://localhost"
+
+								retry {
+									jitter = "random"
+								}
+							}`,
+				ExpectError: regexp.MustCompile(`value\s+must\s+be\s+one\s+of`),
+			},
+		},
+	})
+}
+
+func TestDataSource_RetryNonIdempotent(t *testing.T) {
+	var postRequests atomic.Int32
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			postRequests.Add(1)
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		_, _ = w.Write([]byte("1.0.0"))
+	}))
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url          = "%s"
+								method       = "POST"
+								request_body = "{}"
+
+								retry {
+									attempts             = 2
+									max_delay_ms         = 10
+									retry_non_idempotent = false
+								}
+							}`, testServer.URL),
+				ExpectError: regexp.MustCompile(`giving\s+up\s+after\s+1\s+attempt\(s\)`),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url = "%s"
+
+								retry {
+									attempts             = 2
+									max_delay_ms         = 10
+									retry_non_idempotent = false
+								}
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.http_test", "status_code", "200"),
+					func(_ *terraform.State) error {
+						if n := postRequests.Load(); n != 1 {
+							return fmt.Errorf("expected 1 POST request, got %d", n)
+						}
+
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_ResultValidation(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Region", "eu-west-1")
+
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+
+		_, _ = w.Write([]byte(`{"status": "DEGRADED", "version": "1.0.0"}`))
+	}))
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url = "%s/missing"
+
+								result_validation {
+									status_codes  = [200]
+									error_message = "The service is not deployed"
+								}
+							}`, testServer.URL),
+				ExpectError: regexp.MustCompile(`The\s+service\s+is\s+not\s+deployed(.|\n)*The\s+response\s+status\s+code\s+is\s+404`),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url = "%s"
+
+								result_validation {
+									header        = "X-Region"
+									regex         = "^eu-"
+									error_message = "The service is not in the EU"
+								}
+
+								result_validation {
+									jsonpath      = "$.status"
+									equals        = "HEALTHY"
+									error_message = "The service is not healthy"
+								}
+							}`, testServer.URL),
+				ExpectError: regexp.MustCompile(`The\s+service\s+is\s+not\s+healthy(.|\n)*"\$\.status"\s+is\s+"DEGRADED",\s+not\s+"HEALTHY"`),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url = "%s"
+
+								result_validation {
+									header        = "X-Version"
+									equals        = "1.0.0"
+									error_message = "The version is unknown"
+								}
+							}`, testServer.URL),
+				ExpectError: regexp.MustCompile(`The\s+response\s+header\s+"X-Version"\s+is\s+not\s+present`),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url = "%s"
+
+								result_validation {
+									status_codes  = [200, 201]
+									jsonpath      = "$.version"
+									regex         = "^1\\."
+									error_message = "The major version is not 1"
+								}
+
+								result_validation {
+									regex         = "DEGRADED"
+									error_message = "The body does not contain the status"
+								}
+							}`, testServer.URL),
+				Check: resource.TestCheckResourceAttr("data.http.http_test", "status_code", "200"),
+			},
+		},
+	})
+}
+
+func TestDataSource_ResultValidation_Invalid(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+							data "http" "http_test" {
+								url = "http://localhost"
+
+								result_validation {
+									header        = "X-Region"
+									error_message = "The region is invalid"
+								}
+							}`,
+				ExpectError: regexp.MustCompile(`At\s+least\s+one\s+of\s+the\s+status_codes,\s+equals\s+or\s+regex\s+attributes`),
+			},
+			{
+				Config: `
+							data "http" "http_test" {
+								url = "http://localhost"
+
+								result_validation {
+									jsonpath      = "$["
+									equals        = "HEALTHY"
+									error_message = "The service is not healthy"
+								}
+							}`,
+				ExpectError: regexp.MustCompile(`Invalid\s+JSONPath\s+Expression`),
+			},
+			{
+				Config: `
+							data "http" "http_test" {
+								url = "http://localhost"
+
+								result_validation {
+									header        = "X-Region"
+									jsonpath      = "$.region"
+									equals        = "eu-west-1"
+									error_message = "The region is invalid"
+								}
+							}`,
+				ExpectError: regexp.MustCompile(`Invalid\s+Attribute\s+Combination`),
+			},
+		},
+	})
+}
+
+func TestDataSource_ExpectedResponseBodyRegex(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/unhealthy" {
+			_, _ = w.Write([]byte("status: " + strings.Repeat("unhealthy ", 100)))
+			return
+		}
+
+		_, _ = w.Write([]byte("status: healthy"))
+	}))
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url                          = "%s/unhealthy"
+								expected_response_body_regex = "\\bhealthy\\b"
+							}`, testServer.URL),
+				ExpectError: regexp.MustCompile(`Response\s+body\s+excerpt:\s+status:\s+unhealthy[a-z\s]*\.\.\.`),
+			},
+			{
+				Config: `
+							data "http" "http_test" {
+								url                          = "http://localhost"
+								expected_response_body_regex = "("
+							}`,
+				ExpectError: regexp.MustCompile(`The\s+regular\s+expression\s+could\s+not\s+be\s+compiled`),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url                          = "%s"
+								expected_response_body_regex = "\\bhealthy\\b"
+							}`, testServer.URL),
+				Check: resource.TestCheckResourceAttr("data.http.http_test", "response_body", "status: healthy"),
+			},
+		},
+	})
+}
+
+func TestDataSource_ResultValidationSeverity(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", "true")
+		_, _ = w.Write([]byte("1.0.0"))
+	}))
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url = "%s"
+
+								result_validation {
+									header        = "Deprecation"
+									equals        = "false"
+									error_message = "The endpoint is deprecated"
+									severity      = "warning"
+								}
+
+								result_validation {
+									regex         = "^2\\."
+									error_message = "The version is not 2.x"
+									severity      = "error"
+								}
+							}`, testServer.URL),
+				ExpectError: regexp.MustCompile(`The\s+version\s+is\s+not\s+2\.x`),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url = "%s"
+
+								result_validation {
+									header        = "Deprecation"
+									equals        = "false"
+									error_message = "The endpoint is deprecated"
+									severity      = "warning"
+								}
+							}`, testServer.URL),
+				Check: resource.TestCheckResourceAttr("data.http.http_test", "response_body", "1.0.0"),
+			},
+		},
+	})
+}
+
+func TestDataSource_RetryOn(t *testing.T) {
+	var requests atomic.Int32
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url = "%s"
+
+								retry {
+									attempts     = 3
+									max_delay_ms = 10
+									on           = ["connection_errors"]
+								}
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.http_test", "status_code", "500"),
+					func(_ *terraform.State) error {
+						if n := requests.Load(); n != 1 {
+							return fmt.Errorf("expected 1 request, got %d", n)
+						}
+
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_RetryOn_Invalid(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+							data "http" "http_test" {
+								url = "http://localhost"
+
+								retry {
+									on = ["timeouts"]
+								}
+							}`,
+				ExpectError: regexp.MustCompile(`value\s+must\s+be\s+one\s+of`),
+			},
+		},
+	})
+}
+
+func TestDataSource_RetryIf(t *testing.T) {
+	var requests atomic.Int32
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Path == "/pending" || requests.Add(1) <= 2 {
+			_, _ = w.Write([]byte(`{"state": "PENDING"}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"state": "DONE"}`))
+	}))
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url = "%s/pending"
+
+								retry {
+									attempts     = 2
+									max_delay_ms = 10
+									retry_if     = "status_code == 200 && strcontains(response_body, \"PENDING\") && attempt < 2"
+								}
+							}`, testServer.URL),
+				Check: resource.TestCheckResourceAttr("data.http.http_test", "response_body", `{"state": "PENDING"}`),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url = "%s"
+
+								retry {
+									attempts     = 3
+									max_delay_ms = 10
+									retry_if     = "jsondecode(response_body).state == \"PENDING\""
+								}
+							}`, testServer.URL),
+				Check: resource.TestCheckResourceAttr("data.http.http_test", "response_body", `{"state": "DONE"}`),
+			},
+		},
+	})
+}
+
+func TestDataSource_RetryIf_Invalid(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+							data "http" "http_test" {
+								url = "http://localhost"
+
+								retry {
+									retry_if = "status == 200"
+								}
+							}`,
+				ExpectError: regexp.MustCompile(`unknown\s+variable\s+"status"`),
+			},
+			{
+				Config: `
+							data "http" "http_test" {
+								url = "http://localhost"
+
+								retry {
+									retry_if = "status_code =="
+								}
+							}`,
+				ExpectError: regexp.MustCompile(`The\s+retry_if\s+expression\s+could\s+not\s+be\s+parsed`),
+			},
+		},
+	})
+}
+
+func TestDataSource_ResponseHeaderTimeout(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		_, _ = w.Write([]byte("1.0.0"))
+	}))
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url                        = "%s"
+								response_header_timeout_ms = 20
+							}`, testServer.URL),
+				ExpectError: regexp.MustCompile(`timeout\s+awaiting\s+response\s+headers`),
+			},
+			{
+				Config: fmt.Sprintf(`
+							provider "http" {
+								response_header_timeout_ms = 20
+							}
+
+							data "http" "http_test" {
+								url = "%s"
+							}`, testServer.URL),
+				ExpectError: regexp.MustCompile(`timeout\s+awaiting\s+response\s+headers`),
+			},
+			{
+				Config: fmt.Sprintf(`
+							provider "http" {
+								connect_timeout_ms         = 1000
+								tls_handshake_timeout_ms   = 1000
+								response_header_timeout_ms = 20
+							}
+
+							data "http" "http_test" {
+								url                        = "%s"
+								response_header_timeout_ms = 5000
+							}`, testServer.URL),
+				Check: resource.TestCheckResourceAttr("data.http.http_test", "response_body", "1.0.0"),
+			},
+		},
+	})
+}
+
+func TestDataSource_AttemptsMade(t *testing.T) {
+	var requests atomic.Int32
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/flaky" && requests.Add(1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		_, _ = w.Write([]byte("1.0.0"))
+	}))
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "flaky" {
+								url = "%[1]s/flaky"
+
+								retry {
+									attempts     = 3
+									min_delay_ms = 10
+									max_delay_ms = 10
+								}
+							}
+
+							data "http" "healthy" {
+								url = "%[1]s/healthy"
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.flaky", "attempts_made", "3"),
+					resource.TestCheckResourceAttr("data.http.flaky", "total_retry_wait_ms", "20"),
+					resource.TestCheckResourceAttr("data.http.healthy", "attempts_made", "1"),
+					resource.TestCheckResourceAttr("data.http.healthy", "total_retry_wait_ms", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_ProviderRetry(t *testing.T) {
+	var requests atomic.Int32
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/unavailable" || requests.Add(1)%2 == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		_, _ = w.Write([]byte("1.0.0"))
+	}))
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							provider "http" {
+								retry {
+									attempts     = 3
+									min_delay_ms = 10
+									max_delay_ms = 10
+								}
+							}
+
+							data "http" "http_test" {
+								url = "%s/unavailable"
+
+								retry {
+									attempts = 0
+								}
+							}`, testServer.URL),
+				ExpectError: regexp.MustCompile(`giving\s+up\s+after\s+1\s+attempt\(s\)`),
+			},
+			{
+				Config: fmt.Sprintf(`
+							provider "http" {
+								retry {
+									attempts     = 1
+									min_delay_ms = 10
+									max_delay_ms = 10
+								}
+							}
+
+							data "http" "http_test" {
+								url = "%s"
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.http_test", "status_code", "200"),
+					resource.TestCheckResourceAttr("data.http.http_test", "attempts_made", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_ProviderDefaultRequestTimeout(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(time.Duration(10) * time.Millisecond)
+	}))
+	defer svr.Close()
+
+	t.Setenv("TF_HTTP_REQUEST_TIMEOUT_MS", "1000")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							provider "http" {
+								default_request_timeout_ms = 5
+							}
+
+							data "http" "http_test" {
+  								url = "%s"
+							}`, svr.URL),
+				ExpectError: regexp.MustCompile(`request exceeded the specified timeout: 5ms`),
+			},
+			{
+				Config: fmt.Sprintf(`
+							provider "http" {
+								default_request_timeout_ms = 5
+							}
+
+							data "http" "http_test" {
+  								url = "%s"
+								request_timeout_ms = 1000
+							}`, svr.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.http_test", "status_code", "200"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_ProviderHost(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Authorization", r.Header.Get("Authorization"))
+		w.Header().Set("X-Team", r.Header.Get("X-Team"))
+		w.Header().Set("X-Region", r.Header.Get("X-Region"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer svr.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							provider "http" {
+								host {
+									name = "example.com"
+									request_headers = {
+										X-Region = "eu"
+									}
+								}
+
+								host {
+									name = "127.0.0.1"
+									bearer_token = "token"
+									request_headers = {
+										X-Team   = "platform"
+										X-Region = "us"
+									}
+								}
+							}
+
+							data "http" "http_test" {
+								url = "%s"
+								request_headers = {
+									x-region = "ap"
+								}
+							}`, svr.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.http_test", "response_headers.X-Authorization", "Bearer token"),
+					resource.TestCheckResourceAttr("data.http.http_test", "response_headers.X-Team", "platform"),
+					resource.TestCheckResourceAttr("data.http.http_test", "response_headers.X-Region", "ap"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+							provider "http" {
+								host {
+									name     = "*.example.com"
+									username = "user"
+									password = "password"
+								}
+							}
+
+							data "http" "http_test" {
+								url = "%s"
+							}`, svr.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.http_test", "response_headers.X-Authorization", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_ProviderHostTimeout(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(time.Duration(10) * time.Millisecond)
+	}))
+	defer svr.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							provider "http" {
+								host {
+									name               = "127.0.0.1"
+									request_timeout_ms = 5
+								}
+							}
+
+							data "http" "http_test" {
+  								url = "%s"
+							}`, svr.URL),
+				ExpectError: regexp.MustCompile(`request exceeded the specified timeout: 5ms`),
+			},
+			{
+				Config: fmt.Sprintf(`
+							provider "http" {
+								host {
+									name               = "127.0.0.1"
+									request_timeout_ms = 5
+								}
+							}
+
+							data "http" "http_test" {
+  								url = "%s"
+								request_timeout_ms = 1000
+							}`, svr.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.http_test", "status_code", "200"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_HTTPViaProxyWithProviderConfig(t *testing.T) {
+	proxyRequests := 0
+	serverRequests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serverRequests++
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+
+	if err != nil {
+		t.Fatalf("error parsing server URL: %s", err)
+	}
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Proxy-Authorization") != "Basic "+base64.StdEncoding.EncodeToString([]byte("user:password")) {
+			w.WriteHeader(http.StatusProxyAuthRequired)
+			return
+		}
+
+		proxyRequests++
+		httputil.NewSingleHostReverseProxy(serverURL).ServeHTTP(w, r)
+	}))
+	defer proxy.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					provider "http" {
+						proxy {
+							http_url  = "%s"
+							https_url = "http://terraform-provider-http-test-https-proxy:3128"
+							username  = "user"
+							password  = "password"
+						}
+					}
+
+					data "http" "http_test" {
+						url = "%s"
+					}
+				`, proxy.URL, testProxiedURL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.http_test", "status_code", "200"),
+					checkServerAndProxyRequestCount(&proxyRequests, &serverRequests),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_HTTPViaProxyWithProviderConfig_Invalid(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					provider "http" {
+						proxy {
+							url = "proxy.example.com"
+						}
+					}
+
+					data "http" "http_test" {
+						url = "%s"
+					}
+				`, testProxiedURL),
+				ExpectError: regexp.MustCompile(`Invalid\s+Proxy\s+URL`),
+			},
+		},
+	})
+}
+
+func TestDataSource_HTTPViaProxyWithNoProxy(t *testing.T) {
+	proxyRequests := 0
+	serverRequests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serverRequests++
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+
+	if err != nil {
+		t.Fatalf("error parsing server URL: %s", err)
+	}
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxyRequests++
+		httputil.NewSingleHostReverseProxy(serverURL).ServeHTTP(w, r)
+	}))
+	defer proxy.Close()
+
+	t.Setenv("HTTP_PROXY", proxy.URL)
+	t.Setenv("HTTPS_PROXY", proxy.URL)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+
+		Steps: []resource.TestStep{
+			{
+				// The request bypasses the proxy, so the hardcoded host cannot
+				// be resolved.
+				Config: fmt.Sprintf(`
+					provider "http" {
+						proxy {
+							no_proxy = ["10.0.0.0/8", "terraform-provider-http-test-proxy"]
+						}
+					}
+
+					data "http" "http_test" {
+						url = "%s"
+					}
+				`, testProxiedURL),
+				ExpectError: regexp.MustCompile(`Error\s+making\s+request`),
+			},
+			{
+				Config: fmt.Sprintf(`
+					provider "http" {
+						proxy {
+							no_proxy = ["10.0.0.0/8", ".example.com"]
+						}
+					}
+
+					data "http" "http_test" {
+						url = "%s"
+					}
+				`, testProxiedURL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.http_test", "status_code", "200"),
+					checkServerAndProxyRequestCount(&proxyRequests, &serverRequests),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_HTTPViaProxyWithNoProxy_InvalidCIDR(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					provider "http" {
+						proxy {
+							no_proxy = ["10.0.0.0/33"]
+						}
+					}
+
+					data "http" "http_test" {
+						url = "%s"
+					}
+				`, testProxiedURL),
+				ExpectError: regexp.MustCompile(`Invalid\s+CIDR\s+Range`),
+			},
+		},
+	})
+}
+
+func TestDataSource_HTTPViaProxyWithUseProxyFromEnvDisabled(t *testing.T) {
+	proxyRequests := 0
+	serverRequests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serverRequests++
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+
+	if err != nil {
+		t.Fatalf("error parsing server URL: %s", err)
+	}
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxyRequests++
+		httputil.NewSingleHostReverseProxy(serverURL).ServeHTTP(w, r)
+	}))
+	defer proxy.Close()
+
+	t.Setenv("HTTP_PROXY", proxy.URL)
+	t.Setenv("HTTPS_PROXY", proxy.URL)
+	t.Setenv("TF_HTTP_PROXY", proxy.URL)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+
+		Steps: []resource.TestStep{
+			{
+				// The request bypasses the proxy, so the hardcoded host cannot
+				// be resolved.
+				Config: fmt.Sprintf(`
+					provider "http" {
+						use_proxy_from_env = false
+					}
+
+					data "http" "http_test" {
+						url = "%s"
+					}
+				`, testProxiedURL),
+				ExpectError: regexp.MustCompile(`Error\s+making\s+request`),
+			},
+			{
+				Config: fmt.Sprintf(`
+					provider "http" {
+						use_proxy_from_env = false
+
+						proxy {
+							url = "%s"
+						}
+					}
+
+					data "http" "http_test" {
+						url = "%s"
+					}
+				`, proxy.URL, testProxiedURL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.http_test", "status_code", "200"),
+					checkServerAndProxyRequestCount(&proxyRequests, &serverRequests),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_ProviderRateLimit(t *testing.T) {
+	var mu sync.Mutex
+	var requestTimes []time.Time
+
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requestTimes = append(requestTimes, time.Now())
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer svr.Close()
+
+	resetRequestTimes := func() {
+		mu.Lock()
+		requestTimes = nil
+		mu.Unlock()
+	}
+
+	// Three requests at five requests per second, with a burst of one, take
+	// at least 400ms.
+	checkRequestsSpread := func(*terraform.State) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if len(requestTimes) != 3 {
+			return fmt.Errorf("expected 3 requests, got %d", len(requestTimes))
+		}
+
+		if spread := requestTimes[2].Sub(requestTimes[0]); spread < 350*time.Millisecond {
+			return fmt.Errorf("expected requests to be spread over at least 350ms, got %s", spread)
+		}
+
+		return nil
+	}
+
+	dataSources := fmt.Sprintf(`
+							data "http" "http_test" {
+								count = 3
+								url   = "%s/${count.index}"
+							}`, svr.URL)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				PreConfig: resetRequestTimes,
+				Config: `
+							provider "http" {
+								rate_limit {
+									requests_per_second = 5
+								}
+							}` + dataSources,
+				Check: checkRequestsSpread,
+			},
+			{
+				PreConfig: resetRequestTimes,
+				Config: `
+							provider "http" {
+								host {
+									name = "127.0.0.1"
+
+									rate_limit {
+										requests_per_second = 5
+										burst               = 1
+									}
+								}
+							}` + dataSources,
+				Check: checkRequestsSpread,
+			},
+		},
+	})
+}
+
+func TestDataSource_ProviderMaxConcurrentRequests(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int64
+
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+
+		for {
+			previous := maxInFlight.Load()
+			if current <= previous || maxInFlight.CompareAndSwap(previous, current) {
+				break
+			}
+		}
+
+		time.Sleep(50 * time.Millisecond)
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page < 2 {
+			w.Header().Set("Link", fmt.Sprintf(`<%s?page=%d>; rel="next"`, r.URL.Path, page+1))
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer svr.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							provider "http" {
+								max_concurrent_requests = 1
+							}
+
+							data "http" "http_test" {
+								count = 3
+								url   = "%s/${count.index}"
+							}
+
+							data "http" "paginated" {
+								url = "%s/paginated"
+
+								paginate {
+									mode = "link_header"
+								}
+							}`, svr.URL, svr.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.http_test.2", "status_code", "200"),
+					resource.TestCheckResourceAttr("data.http.paginated", "pages.#", "3"),
+					func(*terraform.State) error {
+						if maxInFlight.Load() != 1 {
+							return fmt.Errorf("expected at most 1 request in flight, got %d", maxInFlight.Load())
+						}
+
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_ProviderConnectionPool(t *testing.T) {
+	var connections atomic.Int64
+
+	svr := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	svr.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			connections.Add(1)
+		}
+	}
+	svr.Start()
+	defer svr.Close()
+
+	checkConnections := func(expected int64) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			if connections.Load() != expected {
+				return fmt.Errorf("expected %d connection(s), got %d", expected, connections.Load())
+			}
+
+			return nil
+		}
+	}
+
+	// The requests are made one at a time, so that a single connection can
+	// be reused by all data sources.
+	dataSources := fmt.Sprintf(`
+							data "http" "http_test" {
+								count = 3
+								url   = "%s/${count.index}"
+							}`, svr.URL)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() { connections.Store(0) },
+				Config: `
+							provider "http" {
+								max_concurrent_requests = 1
+								max_idle_conns_per_host = 1
+								idle_conn_timeout_ms    = 60000
+							}` + dataSources,
+				Check: checkConnections(1),
+			},
+			{
+				PreConfig: func() { connections.Store(0) },
+				Config: `
+							provider "http" {
+								max_concurrent_requests = 1
+								disable_keep_alives     = true
+							}` + dataSources,
+				Check: checkConnections(3),
+			},
+		},
+	})
+}
+
+func TestDataSource_TLSCipherSuites(t *testing.T) {
+	testServer := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	testServer.TLS = &tls.Config{
+		MaxVersion:   tls.VersionTLS12,
+		CipherSuites: []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256},
+	}
+	testServer.StartTLS()
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url      = "%s"
+								insecure = true
+
+								tls_cipher_suites = ["TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"]
+							}`, testServer.URL),
+				ExpectError: regexp.MustCompile(`handshake\s+failure`),
+			},
+			{
+				Config: fmt.Sprintf(`
+							provider "http" {
+								tls_cipher_suites = ["TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"]
+							}
+
+							data "http" "http_test" {
+								url      = "%s"
+								insecure = true
+
+								tls_cipher_suites = [
+									"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+									"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+								]
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.http_test", "tls_cipher_suite", "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_TLSCipherSuites_Invalid(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+							data "http" "http_test" {
+								url = "https://localhost"
+
+								tls_cipher_suites = ["TLS_UNKNOWN"]
+							}`,
+				ExpectError: regexp.MustCompile(`value must be one of`),
+			},
+		},
+	})
+}
+
+func TestDataSource_TLSRenegotiation(t *testing.T) {
+	testServer := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	testServer.TLS = &tls.Config{
+		MaxVersion: tls.VersionTLS12,
+	}
+	testServer.StartTLS()
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							provider "http" {
+								tls_renegotiation = "once"
+							}
+
+							data "http" "http_test" {
+								url      = "%s"
+								insecure = true
+
+								tls_renegotiation = "freely"
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.http_test", "status_code", "200"),
+					resource.TestCheckResourceAttr("data.http.http_test", "tls_renegotiation", "freely"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_TLSRenegotiation_Invalid(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+							data "http" "http_test" {
+								url = "https://localhost"
+
+								tls_renegotiation = "always"
+							}`,
+				ExpectError: regexp.MustCompile(`value must be one of`),
+			},
+		},
+	})
+}
+
+func TestDataSource_PinnedPublicKeys(t *testing.T) {
+	testServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer testServer.Close()
+
+	hash := sha256.Sum256(testServer.Certificate().RawSubjectPublicKeyInfo)
+	pin := "sha256/" + base64.StdEncoding.EncodeToString(hash[:])
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url = "%s"
+
+								pinned_public_keys = ["sha256/AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="]
+							}`, testServer.URL),
+				ExpectError: regexp.MustCompile(`server\s+certificate\s+does\s+not\s+have\s+a\s+pinned\s+public\s+key`),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url = "%s"
+
+								pinned_public_keys = [
+									"sha256/AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA=",
+									%q,
+								]
+							}`, testServer.URL, pin),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.http_test", "status_code", "200"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_PinnedPublicKeys_Issuer(t *testing.T) {
+	ca := newTestCertificateAuthority(t, "Test CA")
+
+	testServer := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	testServer.TLS = &tls.Config{
+		Certificates: []tls.Certificate{ca.serverCertificate(t, 2)},
+	}
+	testServer.StartTLS()
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url = "%s"
+
+								pinned_public_keys = [%q]
+							}`, testServer.URL, publicKeyPin(ca.cert)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.http_test", "status_code", "200"),
+				),
+			},
+		},
+	})
+}
+
+// TestDataSource_PinnedPublicKeys_ForgedLeaf verifies that a server presenting
+// its own certificate followed by the certificates of another server, which
+// have pinned public keys, is rejected.
+func TestDataSource_PinnedPublicKeys_ForgedLeaf(t *testing.T) {
+	ca := newTestCertificateAuthority(t, "Test CA")
+	attacker := newTestCertificateAuthority(t, "Attacker CA")
+
+	realCertificate := ca.serverCertificate(t, 2)
+	realLeaf, err := x509.ParseCertificate(realCertificate.Certificate[0])
+	if err != nil {
+		t.Fatalf("error parsing server certificate: %s", err)
+	}
+
+	forgedCertificate := attacker.serverCertificate(t, 2)
+	forgedCertificate.Certificate = append(forgedCertificate.Certificate, realCertificate.Certificate...)
+
+	testServer := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	testServer.TLS = &tls.Config{
+		Certificates: []tls.Certificate{forgedCertificate},
+	}
+	testServer.StartTLS()
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url = "%s"
+
+								pinned_public_keys = [%q, %q]
+							}`, testServer.URL, publicKeyPin(realLeaf), publicKeyPin(ca.cert)),
+				ExpectError: regexp.MustCompile(`server\s+certificate\s+does\s+not\s+have\s+a\s+pinned\s+public\s+key`),
+			},
+		},
+	})
+}
+
+func TestDataSource_PinnedPublicKeys_Invalid(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+							data "http" "http_test" {
+								url = "https://localhost"
+
+								pinned_public_keys = ["AAAA"]
+							}`,
+				ExpectError: regexp.MustCompile(`must\s+be\s+a\s+base64\s+encoded\s+SHA-256\s+hash`),
+			},
+		},
+	})
+}
+
+func TestDataSource_CRL(t *testing.T) {
+	ca := newTestCertificateAuthority(t, "Test CA")
+
+	testServer := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	testServer.TLS = &tls.Config{
+		Certificates: []tls.Certificate{ca.serverCertificate(t, 2)},
+	}
+	testServer.StartTLS()
+	defer testServer.Close()
+
+	crlServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pkix-crl")
+		_, _ = w.Write(ca.crl(t, 3))
+	}))
+	defer crlServer.Close()
+
+	revokedCRL := string(pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: ca.crl(t, 2, 3)}))
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url = "%s"
+
+								ca_cert_pem = <<EOF
+%s
+EOF
+
+								crl_pem = <<EOF
+%s
+EOF
+							}`, testServer.URL, certToPEM(ca.cert), revokedCRL),
+				ExpectError: regexp.MustCompile(`with\s+serial\s+number\s+2\s+was\s+revoked`),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url = "%s"
+
+								ca_cert_pem = <<EOF
+%s
+EOF
+
+								crl_url = "%s"
+							}`, testServer.URL, certToPEM(ca.cert), crlServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.http_test", "status_code", "200"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_CRL_MissingIssuerCRL(t *testing.T) {
+	ca := newTestCertificateAuthority(t, "Test CA")
+	otherCA := newTestCertificateAuthority(t, "Other Test CA")
+
+	testServer := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	testServer.TLS = &tls.Config{
+		Certificates: []tls.Certificate{ca.serverCertificate(t, 2)},
+	}
+	testServer.StartTLS()
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http" "http_test" {
+								url = "%s"
+
+								ca_cert_pem = <<EOF
+%s
+EOF
+
+								crl_pem = <<EOF
+%s
+EOF
+							}`, testServer.URL, certToPEM(ca.cert), pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: otherCA.crl(t)})),
+				ExpectError: regexp.MustCompile(`no\s+certificate\s+revocation\s+list\s+\(CRL\)\s+of\s+the\s+issuer`),
+			},
+		},
+	})
+}
+
+func TestDataSource_CRL_Invalid(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+							data "http" "http_test" {
+								url = "https://localhost"
+
+								crl_pem = <<EOF
+-----BEGIN CERTIFICATE-----
+MIIB
+-----END CERTIFICATE-----
+EOF
+							}`,
+				ExpectError: regexp.MustCompile(`Invalid\s+Certificate\s+Revocation\s+List`),
+			},
+		},
+	})
+}
+
+func TestDataSource_OCSP(t *testing.T) {
+	ca := newTestCertificateAuthority(t, "Test CA")
+
+	newServer := func(certificate tls.Certificate) *httptest.Server {
+		server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		server.TLS = &tls.Config{
+			Certificates: []tls.Certificate{certificate},
+		}
+		server.StartTLS()
+
+		return server
+	}
+
+	// The responder reports the certificate with serial number 3 as revoked.
+	responder := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		request, err := ocsp.ParseRequest(body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		status := ocsp.Good
+		if request.SerialNumber.Int64() == 3 {
+			status = ocsp.Revoked
+		}
+
+		w.Header().Set("Content-Type", "application/ocsp-response")
+		_, _ = w.Write(ca.ocspResponse(t, request.SerialNumber.Int64(), status))
+	}))
+	defer responder.Close()
+
+	unstapled := newServer(ca.serverCertificate(t, 2, responder.URL))
+	defer unstapled.Close()
+
+	revoked := newServer(ca.serverCertificate(t, 3, responder.URL))
+	defer revoked.Close()
+
+	stapledCertificate := ca.serverCertificate(t, 4)
+	stapledCertificate.OCSPStaple = ca.ocspResponse(t, 4, ocsp.Good)
+	stapled := newServer(stapledCertificate)
+	defer stapled.Close()
+
+	config := func(url string, attributes string) string {
+		return fmt.Sprintf(`
+							data "http" "http_test" {
+								url = "%s"
+
+								ca_cert_pem = <<EOF
+%s
+EOF
+
+								%s
+							}`, url, certToPEM(ca.cert), attributes)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config:      config(unstapled.URL, "require_ocsp_staple = true"),
+				ExpectError: regexp.MustCompile(`the\s+server\s+did\s+not\s+staple\s+an\s+OCSP\s+response`),
+			},
+			{
+				Config:      config(revoked.URL, "ocsp_check = true"),
+				ExpectError: regexp.MustCompile(`with\s+serial\s+number\s+3\s+was\s+revoked\s+at\s+.+\s+according\s+to\s+OCSP`),
+			},
+			{
+				Config: config(unstapled.URL, "ocsp_check = true"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.http_test", "status_code", "200"),
+				),
+			},
+			{
+				Config: config(stapled.URL, "require_ocsp_staple = true"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.http_test", "status_code", "200"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_ProviderEndpoint(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte(r.URL.RequestURI()))
+	}))
+	defer testServer.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							provider "http" {
+								endpoint {
+									name     = "artifactory"
+									base_url = "%s/api/"
+								}
+							}
+
+							data "http" "http_test" {
+								endpoint = "registry"
+								url      = "/storage/libs"
+							}`, testServer.URL),
+				ExpectError: regexp.MustCompile(`the\s+endpoint\s+"registry"\s+is\s+not\s+configured\s+in\s+the\s+provider`),
+			},
+			{
+				Config: fmt.Sprintf(`
+							provider "http" {
+								endpoint {
+									name     = "artifactory"
+									base_url = "%s/api/"
+								}
+							}
+
+							data "http" "http_test" {
+								endpoint = "artifactory"
+								url      = "/storage/libs?list"
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http.http_test", "id", testServer.URL+"/api/storage/libs?list"),
+					resource.TestCheckResourceAttr("data.http.http_test", "response_body", "/api/storage/libs?list"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSource_ProviderTracing(t *testing.T) {
+	var mu sync.Mutex
+	var spans []*tracev1.Span
+	var authorization, traceparent string
+
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		var request coltracev1.ExportTraceServiceRequest
+		if err := proto.Unmarshal(body, &request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		authorization = r.Header.Get("Authorization")
+
+		for _, resourceSpans := range request.GetResourceSpans() {
+			for _, scopeSpans := range resourceSpans.GetScopeSpans() {
+				spans = append(spans, scopeSpans.GetSpans()...)
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/x-protobuf")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer collector.Close()
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		traceparent = r.Header.Get("Traceparent")
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer testServer.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							provider "http" {
+								tracing {
+									endpoint = "%s/v1/traces"
+									headers = {
+										Authorization = "Bearer collector-token"
+									}
+								}
+							}
+
+							data "http" "http_test" {
+								url = "%s"
+							}`, collector.URL, testServer.URL),
+				Check: func(_ *terraform.State) error {
+					mu.Lock()
+					defer mu.Unlock()
+
+					if authorization != "Bearer collector-token" {
+						return fmt.Errorf("expected the collector to receive the configured headers, got Authorization %q", authorization)
+					}
+
+					var read, attempt *tracev1.Span
+
+					for _, span := range spans {
+						switch span.GetName() {
+						case "data.http":
+							read = span
+						case "HTTP GET":
+							attempt = span
+						}
+					}
+
+					if read == nil || attempt == nil {
+						return fmt.Errorf("expected spans of the read and of the request attempt, got %d spans", len(spans))
+					}
+
+					if string(attempt.GetParentSpanId()) != string(read.GetSpanId()) {
+						return fmt.Errorf("expected the span of the request attempt to be a child of the span of the read")
+					}
+
+					expected := fmt.Sprintf("00-%x-%x-01", attempt.GetTraceId(), attempt.GetSpanId())
+					if traceparent != expected {
+						return fmt.Errorf("expected traceparent header %q, got %q", expected, traceparent)
+					}
+
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestDataSource_ProviderMetricsReport(t *testing.T) {
+	var requestCount atomic.Int64
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The first request fails, so that it is retried.
+		if requestCount.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer testServer.Close()
+
+	metrics := NewMetrics()
+	reportPath := filepath.Join(t.TempDir(), "metrics.json")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: map[string]func() (tfprotov5.ProviderServer, error){
+			"http": providerserver.NewProtocol5WithError(NewWithMetrics(metrics)()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							provider "http" {
+								metrics_report_path = %q
+							}
+
+							data "http" "http_test" {
+								url = "%s"
+
+								retry {
+									attempts     = 1
+									min_delay_ms = 10
+								}
+							}`, reportPath, testServer.URL),
+				Check: resource.TestCheckResourceAttr("data.http.http_test", "status_code", "200"),
+			},
+		},
+	})
+
+	// The report is written during the reads, without the provider shutting
+	// down.
+	report, err := os.ReadFile(reportPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var summary struct {
+		Hosts []hostMetrics `json:"hosts"`
+	}
+
+	if err := json.Unmarshal(report, &summary); err != nil {
+		t.Fatal(err)
+	}
+
+	serverURL, err := url.Parse(testServer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(summary.Hosts) != 1 {
+		t.Fatalf("expected a report of 1 host, got %d", len(summary.Hosts))
+	}
+
+	host := summary.Hosts[0]
+
+	if host.Host != serverURL.Host {
+		t.Errorf("expected a report of host %q, got %q", serverURL.Host, host.Host)
+	}
+
+	if host.Requests != requestCount.Load() {
+		t.Errorf("expected %d requests, got %d", requestCount.Load(), host.Requests)
+	}
+
+	if host.Failures != 1 {
+		t.Errorf("expected 1 failure, got %d", host.Failures)
+	}
+
+	if host.Retries != 1 {
+		t.Errorf("expected 1 retry, got %d", host.Retries)
+	}
+}
+
+func TestDataSource_ProviderRetryBudget(t *testing.T) {
+	var requestCount atomic.Int64
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer testServer.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							provider "http" {
+								retry_budget = 1
+							}
+
+							data "http" "http_test" {
+								url = "%s"
+
+								retry {
+									attempts     = 3
+									min_delay_ms = 10
+									max_delay_ms = 10
+								}
+							}`, testServer.URL),
+				ExpectError: regexp.MustCompile(`(?s)Retry\s+Budget\s+Exhausted.*retry_budget\s+of\s+1\s+retries\s+has\s+been\s+spent.*503`),
+			},
+		},
+	})
+
+	// The first attempt is retried once, after which the budget is spent.
+	if got := requestCount.Load(); got != 2 {
+		t.Errorf("expected 2 requests, got %d", got)
+	}
+}
+
 func checkServerAndProxyRequestCount(proxyRequestCount, serverRequestCount *int) resource.TestCheckFunc {
 	return func(_ *terraform.State) error {
 		if *proxyRequestCount != *serverRequestCount {
@@ -995,3 +4813,123 @@ func checkMinDelay(timeOfFirstRequest, timeOfSecondRequest *int64, minDelay int)
 		return nil
 	}
 }
+
+// testCertificateAuthority is a certificate authority used in acceptance
+// testing of certificate revocation.
+type testCertificateAuthority struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCertificateAuthority(t *testing.T, commonName string) *testCertificateAuthority {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("error generating key: %s", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("error creating CA certificate: %s", err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("error parsing CA certificate: %s", err)
+	}
+
+	return &testCertificateAuthority{cert: cert, key: key}
+}
+
+// serverCertificate returns a certificate for 127.0.0.1 with the given serial
+// number and OCSP responders, issued by the certificate authority.
+func (ca *testCertificateAuthority) serverCertificate(t *testing.T, serialNumber int64, ocspServers ...string) tls.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("error generating key: %s", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serialNumber),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		OCSPServer:   ocspServers,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatalf("error creating server certificate: %s", err)
+	}
+
+	return tls.Certificate{
+		Certificate: [][]byte{der, ca.cert.Raw},
+		PrivateKey:  key,
+	}
+}
+
+// crl returns a DER encoded certificate revocation list of the certificate
+// authority, revoking the given serial numbers.
+func (ca *testCertificateAuthority) crl(t *testing.T, revokedSerialNumbers ...int64) []byte {
+	t.Helper()
+
+	template := &x509.RevocationList{
+		Number:     big.NewInt(1),
+		ThisUpdate: time.Now().Add(-time.Hour),
+		NextUpdate: time.Now().Add(time.Hour),
+	}
+
+	for _, serialNumber := range revokedSerialNumbers {
+		template.RevokedCertificateEntries = append(template.RevokedCertificateEntries, x509.RevocationListEntry{
+			SerialNumber:   big.NewInt(serialNumber),
+			RevocationTime: time.Now().Add(-time.Minute),
+		})
+	}
+
+	der, err := x509.CreateRevocationList(rand.Reader, template, ca.cert, ca.key)
+	if err != nil {
+		t.Fatalf("error creating CRL: %s", err)
+	}
+
+	return der
+}
+
+// ocspResponse returns an OCSP response of the certificate authority with the
+// given status for the certificate with the serial number.
+func (ca *testCertificateAuthority) ocspResponse(t *testing.T, serialNumber int64, status int) []byte {
+	t.Helper()
+
+	template := ocsp.Response{
+		Status:       status,
+		SerialNumber: big.NewInt(serialNumber),
+		ThisUpdate:   time.Now().Add(-time.Hour),
+		NextUpdate:   time.Now().Add(time.Hour),
+	}
+
+	if status == ocsp.Revoked {
+		template.RevokedAt = time.Now().Add(-time.Minute)
+	}
+
+	response, err := ocsp.CreateResponse(ca.cert, ca.cert, template, ca.key)
+	if err != nil {
+		t.Fatalf("error creating OCSP response: %s", err)
+	}
+
+	return response
+}
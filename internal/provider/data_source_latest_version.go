@@ -0,0 +1,389 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ohler55/ojg/jp"
+)
+
+var (
+	_ datasource.DataSource                   = (*latestVersionDataSource)(nil)
+	_ datasource.DataSourceWithConfigure      = (*latestVersionDataSource)(nil)
+	_ datasource.DataSourceWithValidateConfig = (*latestVersionDataSource)(nil)
+)
+
+func NewLatestVersionDataSource() datasource.DataSource {
+	return &latestVersionDataSource{}
+}
+
+type latestVersionDataSource struct {
+	providerData *providerData
+}
+
+func (d *latestVersionDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	data, diags := configureProviderData(req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+	d.providerData = data
+}
+
+func (d *latestVersionDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_latest_version"
+}
+
+func (d *latestVersionDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `
+The ` + "`http_latest_version`" + ` data source makes an HTTP GET request to a JSON or text (e.g., HTML)
+index of releases, extracts the versions it lists and exports the latest one.
+
+Versions are extracted using either ` + "`version_jsonpath`" + ` or ` + "`version_regex`" + `. Values which
+cannot be parsed as a version are ignored. A leading ` + "`v`" + ` is permitted (e.g., ` + "`v1.2.3`" + `).
+Pre-release versions are ignored unless ` + "`allow_prereleases`" + ` is ` + "`true`" + `.
+`,
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The URL used for the request.",
+				Computed:    true,
+			},
+
+			"url": schema.StringAttribute{
+				Description: "The URL of the release index. Supported schemes are `http` and `https`.",
+				Required:    true,
+			},
+
+			"request_headers": schema.MapAttribute{
+				Description: "A map of request header field names and values.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+
+			"request_timeout_ms": schema.Int64Attribute{
+				Description: "The request timeout in milliseconds.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"ca_cert_pem": schema.StringAttribute{
+				Description: "Certificate data of the Certificate Authority (CA) " +
+					"in [PEM (RFC 1421)](https://datatracker.ietf.org/doc/html/rfc1421) format.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("insecure")),
+				},
+			},
+
+			"insecure": schema.BoolAttribute{
+				Description: "Disables verification of the server's certificate chain and hostname. Defaults to `false`",
+				Optional:    true,
+			},
+
+			"version_jsonpath": schema.StringAttribute{
+				Description: "A [JSONPath](https://datatracker.ietf.org/doc/html/rfc9535) expression selecting the versions " +
+					"listed in the JSON response body, such as `$[*].tag_name`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("version_regex")),
+				},
+			},
+
+			"version_regex": schema.StringAttribute{
+				Description: "A regular expression matching the versions listed in the response body. " +
+					"The version is taken from the capture group named `version` if present, otherwise the first " +
+					"capture group if present, otherwise the whole match.",
+				Optional: true,
+			},
+
+			"version_constraint": schema.StringAttribute{
+				Description: "A version constraint, such as `~> 1.2`, which the latest version must satisfy.",
+				Optional:    true,
+			},
+
+			"allow_prereleases": schema.BoolAttribute{
+				Description: "Whether pre-release versions, such as `1.2.0-beta1`, are considered. Defaults to `false`.",
+				Optional:    true,
+			},
+
+			"download_url_template": schema.StringAttribute{
+				Description: "A template for `download_url`, in which each occurrence of `{version}` is replaced with " +
+					"the latest version as listed in the index.",
+				Optional: true,
+			},
+
+			"latest_version": schema.StringAttribute{
+				Description: "The latest version, as listed in the index.",
+				Computed:    true,
+			},
+
+			"versions": schema.ListAttribute{
+				Description: "All versions found which satisfy `version_constraint` and `allow_prereleases`, " +
+					"as listed in the index and sorted from latest to oldest.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+
+			"download_url": schema.StringAttribute{
+				Description: "The result of `download_url_template` for the latest version. " +
+					"This is `null` if `download_url_template` is not configured.",
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *latestVersionDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var model latestVersionModelV0
+	diags := req.Config.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !model.VersionJSONPath.IsNull() && !model.VersionJSONPath.IsUnknown() {
+		if _, err := jp.ParseString(model.VersionJSONPath.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("version_jsonpath"),
+				"Invalid JSONPath Expression",
+				fmt.Sprintf("The JSONPath expression could not be parsed: %s", err),
+			)
+		}
+	}
+
+	if !model.VersionRegex.IsNull() && !model.VersionRegex.IsUnknown() {
+		if _, err := regexp.Compile(model.VersionRegex.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("version_regex"),
+				"Invalid Regular Expression",
+				fmt.Sprintf("The regular expression could not be compiled: %s", err),
+			)
+		}
+	}
+
+	if !model.VersionConstraint.IsNull() && !model.VersionConstraint.IsUnknown() {
+		if _, err := version.NewConstraint(model.VersionConstraint.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("version_constraint"),
+				"Invalid Version Constraint",
+				fmt.Sprintf("The version constraint could not be parsed: %s", err),
+			)
+		}
+	}
+}
+
+func (d *latestVersionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	// Export the spans of the requests, if tracing is configured.
+	defer d.providerData.flushSpans(ctx)
+
+	var model latestVersionModelV0
+	diags := req.Config.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	requestURL := model.URL.ValueString()
+
+	providerData := d.providerData.forURL(requestURL)
+
+	requestHeaders, diags := requestHeadersWithDefaults(providerData, model.RequestHeaders)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tr, diags := newTransport(providerData, model.CaCertificate, model.Insecure)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, body, diags := doGetRequest(ctx, roundTripper(providerData, tr), requestURL, requestHeaders, requestTimeout(providerData, model.RequestTimeout))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		resp.Diagnostics.AddError(
+			"Unexpected response status code",
+			fmt.Sprintf("The release index %s responded with status code %d.", requestURL, response.StatusCode),
+		)
+		return
+	}
+
+	candidates, diags := extractVersionCandidates(model, body)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var constraint version.Constraints
+	if !model.VersionConstraint.IsNull() {
+		var err error
+		constraint, err = version.NewConstraint(model.VersionConstraint.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("version_constraint"),
+				"Invalid Version Constraint",
+				fmt.Sprintf("The version constraint could not be parsed: %s", err),
+			)
+			return
+		}
+	}
+
+	type listedVersion struct {
+		raw     string
+		version *version.Version
+	}
+
+	var listed []listedVersion
+	seen := make(map[string]bool)
+
+	for _, candidate := range candidates {
+		v, err := version.NewVersion(candidate)
+		if err != nil {
+			continue
+		}
+
+		if v.Prerelease() != "" && !model.AllowPrereleases.ValueBool() {
+			continue
+		}
+
+		if constraint != nil && !constraint.Check(v) {
+			continue
+		}
+
+		if seen[candidate] {
+			continue
+		}
+		seen[candidate] = true
+
+		listed = append(listed, listedVersion{raw: candidate, version: v})
+	}
+
+	if len(listed) == 0 {
+		resp.Diagnostics.AddError(
+			"No versions found",
+			fmt.Sprintf("The release index %s did not list any versions matching the configured criteria.", requestURL),
+		)
+		return
+	}
+
+	sort.SliceStable(listed, func(i, j int) bool {
+		return listed[i].version.GreaterThan(listed[j].version)
+	})
+
+	versions := make([]string, 0, len(listed))
+	for _, v := range listed {
+		versions = append(versions, v.raw)
+	}
+
+	latest := listed[0].raw
+
+	model.ID = types.StringValue(requestURL)
+	model.LatestVersion = types.StringValue(latest)
+	model.Versions = versions
+	model.DownloadURL = types.StringNull()
+
+	if !model.DownloadURLTemplate.IsNull() {
+		model.DownloadURL = types.StringValue(strings.ReplaceAll(model.DownloadURLTemplate.ValueString(), "{version}", latest))
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+// extractVersionCandidates returns the strings selected by version_jsonpath
+// or version_regex, which may or may not be valid versions.
+func extractVersionCandidates(model latestVersionModelV0, body []byte) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var candidates []string
+
+	if !model.VersionJSONPath.IsNull() {
+		document, err := parseJSONPathDocument(body)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("version_jsonpath"),
+				"Error evaluating JSONPath expression",
+				fmt.Sprintf("The response body could not be parsed as JSON: %s", err),
+			)
+			return nil, diags
+		}
+
+		x, err := jp.ParseString(model.VersionJSONPath.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("version_jsonpath"),
+				"Invalid JSONPath Expression",
+				fmt.Sprintf("The JSONPath expression could not be parsed: %s", err),
+			)
+			return nil, diags
+		}
+
+		for _, result := range x.Get(document) {
+			if s, ok := result.(string); ok {
+				candidates = append(candidates, s)
+			}
+		}
+
+		return candidates, diags
+	}
+
+	re, err := regexp.Compile(model.VersionRegex.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("version_regex"),
+			"Invalid Regular Expression",
+			fmt.Sprintf("The regular expression could not be compiled: %s", err),
+		)
+		return nil, diags
+	}
+
+	group := 0
+	if i := re.SubexpIndex("version"); i > 0 {
+		group = i
+	} else if re.NumSubexp() > 0 {
+		group = 1
+	}
+
+	for _, match := range re.FindAllStringSubmatch(string(body), -1) {
+		candidates = append(candidates, match[group])
+	}
+
+	return candidates, diags
+}
+
+type latestVersionModelV0 struct {
+	ID                  types.String `tfsdk:"id"`
+	URL                 types.String `tfsdk:"url"`
+	RequestHeaders      types.Map    `tfsdk:"request_headers"`
+	RequestTimeout      types.Int64  `tfsdk:"request_timeout_ms"`
+	CaCertificate       types.String `tfsdk:"ca_cert_pem"`
+	Insecure            types.Bool   `tfsdk:"insecure"`
+	VersionJSONPath     types.String `tfsdk:"version_jsonpath"`
+	VersionRegex        types.String `tfsdk:"version_regex"`
+	VersionConstraint   types.String `tfsdk:"version_constraint"`
+	AllowPrereleases    types.Bool   `tfsdk:"allow_prereleases"`
+	DownloadURLTemplate types.String `tfsdk:"download_url_template"`
+	LatestVersion       types.String `tfsdk:"latest_version"`
+	Versions            []string     `tfsdk:"versions"`
+	DownloadURL         types.String `tfsdk:"download_url"`
+}
@@ -0,0 +1,143 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestLatestVersionDataSource_JSONPath(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[
+			{"tag_name": "v1.10.0"},
+			{"tag_name": "v2.0.0-beta1"},
+			{"tag_name": "v1.9.3"},
+			{"tag_name": "nightly"},
+			{"tag_name": "v1.2.0"}
+		]`))
+	}))
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http_latest_version" "test" {
+								url                   = "%s"
+								version_jsonpath      = "$[*].tag_name"
+								download_url_template = "https://example.com/{version}/app_{version}.zip"
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http_latest_version.test", "latest_version", "v1.10.0"),
+					resource.TestCheckResourceAttr("data.http_latest_version.test", "versions.#", "3"),
+					resource.TestCheckResourceAttr("data.http_latest_version.test", "versions.1", "v1.9.3"),
+					resource.TestCheckResourceAttr("data.http_latest_version.test", "versions.2", "v1.2.0"),
+					resource.TestCheckResourceAttr("data.http_latest_version.test", "download_url", "https://example.com/v1.10.0/app_v1.10.0.zip"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "http_latest_version" "test" {
+								url               = "%s"
+								version_jsonpath  = "$[*].tag_name"
+								allow_prereleases = true
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http_latest_version.test", "latest_version", "v2.0.0-beta1"),
+					resource.TestCheckNoResourceAttr("data.http_latest_version.test", "download_url"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "http_latest_version" "test" {
+								url                = "%s"
+								version_jsonpath   = "$[*].tag_name"
+								version_constraint = "~> 1.9.0"
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http_latest_version.test", "latest_version", "v1.9.3"),
+					resource.TestCheckResourceAttr("data.http_latest_version.test", "versions.#", "1"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+							data "http_latest_version" "test" {
+								url                = "%s"
+								version_jsonpath   = "$[*].tag_name"
+								version_constraint = ">= 3.0"
+							}`, testServer.URL),
+				ExpectError: regexp.MustCompile(`No versions found`),
+			},
+		},
+	})
+}
+
+func TestLatestVersionDataSource_Regex(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<ul>
+			<li><a href="/app/0.9.1/">app_0.9.1</a></li>
+			<li><a href="/app/0.10.0/">app_0.10.0</a></li>
+			<li><a href="/app/0.8.0/">app_0.8.0</a></li>
+		</ul>`))
+	}))
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http_latest_version" "test" {
+								url           = "%s"
+								version_regex = "app_(?P<version>[0-9.]+)<"
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http_latest_version.test", "latest_version", "0.10.0"),
+					resource.TestCheckResourceAttr("data.http_latest_version.test", "versions.#", "3"),
+				),
+			},
+		},
+	})
+}
+
+func TestLatestVersionDataSource_InvalidConfig(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+							data "http_latest_version" "test" {
+								url = "https://example.com"
+							}`,
+				ExpectError: regexp.MustCompile(`No attribute specified when one \(and only one\) of`),
+			},
+			{
+				Config: `
+							data "http_latest_version" "test" {
+								url           = "https://example.com"
+								version_regex = "app_(["
+							}`,
+				ExpectError: regexp.MustCompile(`Invalid Regular Expression`),
+			},
+			{
+				Config: `
+							data "http_latest_version" "test" {
+								url                = "https://example.com"
+								version_regex      = "app_([0-9.]+)"
+								version_constraint = "not a constraint"
+							}`,
+				ExpectError: regexp.MustCompile(`Invalid Version Constraint`),
+			},
+		},
+	})
+}
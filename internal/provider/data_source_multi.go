@@ -0,0 +1,432 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var (
+	_ datasource.DataSource                   = (*multiDataSource)(nil)
+	_ datasource.DataSourceWithConfigure      = (*multiDataSource)(nil)
+	_ datasource.DataSourceWithValidateConfig = (*multiDataSource)(nil)
+)
+
+// multiDefaultMaxConcurrency is the default `max_concurrency` of the
+// `http_multi` data source.
+const multiDefaultMaxConcurrency = 8
+
+func NewMultiDataSource() datasource.DataSource {
+	return &multiDataSource{}
+}
+
+type multiDataSource struct {
+	providerData *providerData
+}
+
+func (d *multiDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	data, diags := configureProviderData(req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+	d.providerData = data
+}
+
+func (d *multiDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_multi"
+}
+
+func (d *multiDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `
+The ` + "`http_multi`" + ` data source makes HTTP GET requests to many URLs concurrently, sharing the same
+request headers, TLS and retry configuration, and exports the response of each URL by its key.
+
+This is considerably faster than one ` + "`http`" + ` data source per URL when there are many URLs. The
+number of requests in flight at the same time is bounded by ` + "`max_concurrency`" + `, in addition to the
+provider ` + "`max_concurrent_requests`" + ` limit.
+
+As with the ` + "`http`" + ` data source, responses with a status code outside the 2xx range are not errors, so
+the ` + "`status_code`" + ` of each response should be checked. A request which fails without a response,
+after any retries, fails the read.
+`,
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "A placeholder identifier, which is always `http_multi`.",
+				Computed:    true,
+			},
+
+			"urls": schema.MapAttribute{
+				Description: "A map of keys to the URLs to request. Supported schemes are `http` and `https`. " +
+					"A list of URLs can be converted using a `for` expression, e.g. `{ for url in var.urls : url => url }`.",
+				ElementType: types.StringType,
+				Required:    true,
+				Validators: []validator.Map{
+					mapvalidator.SizeAtLeast(1),
+					mapvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+
+			"request_headers": schema.MapAttribute{
+				Description: "A map of request header field names and values, which are sent with each request.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+
+			"request_timeout_ms": schema.Int64Attribute{
+				Description: "The timeout of each request in milliseconds.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"max_concurrency": schema.Int64Attribute{
+				Description: fmt.Sprintf("The maximum number of requests in flight at the same time. Defaults to `%d`.",
+					multiDefaultMaxConcurrency),
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"ca_cert_pem": schema.StringAttribute{
+				Description: "Certificate data of the Certificate Authority (CA) " +
+					"in [PEM (RFC 1421)](https://datatracker.ietf.org/doc/html/rfc1421) format.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("insecure")),
+				},
+			},
+
+			"insecure": schema.BoolAttribute{
+				Description: "Disables verification of the server's certificate chain and hostname. Defaults to `false`",
+				Optional:    true,
+			},
+
+			"responses": schema.MapAttribute{
+				Description: "The responses, by the keys of `urls`. Each response has the `url` used for the request, " +
+					"the `status_code`, the `response_headers`, of which duplicates are concatenated according to " +
+					"[RFC2616](https://www.w3.org/Protocols/rfc2616/rfc2616-sec4.html#sec4.2), and the `response_body` " +
+					"returned as a string.",
+				ElementType: types.ObjectType{AttrTypes: multiResponseAttrTypes},
+				Computed:    true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"retry": retryBlock(
+				"Retry configuration of each request. By default there are no retries. Configuring this block " +
+					"will result in retries if an error is returned by the client (e.g., connection errors) or if a 5xx-range " +
+					"(except 501) status code is received. If this block is not configured, the provider's `retry` block " +
+					"is used. The options are the same as those of the `retry` block of the `http` data source.",
+			),
+		},
+	}
+}
+
+func (d *multiDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var model multiModelV0
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateRetry(ctx, model.Retry)...)
+}
+
+func (d *multiDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	// Export the spans of the requests, if tracing is configured.
+	defer d.providerData.flushSpans(ctx)
+
+	var model multiModelV0
+	diags := req.Config.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var urls map[string]string
+	resp.Diagnostics.Append(model.URLs.ElementsAs(ctx, &urls, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var retry retryModel
+
+	if !model.Retry.IsNull() {
+		diags = model.Retry.As(ctx, &retry, basetypes.ObjectAsOptions{})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else if d.providerData != nil && d.providerData.retry != nil {
+		retry = *d.providerData.retry
+	}
+
+	// The keys are requested in order, so that any errors are reported in a
+	// deterministic order.
+	keys := make([]string, 0, len(urls))
+	for key := range urls {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	results := make([]multiResult, len(keys))
+
+	// Each request is configured before any is made, so that configuration
+	// errors are reported without making requests.
+	for i, key := range keys {
+		results[i].providerData = d.providerData.forURL(urls[key])
+		results[i].client, diags = newMultiRetryClient(ctx, results[i].providerData, model, retry)
+		results[i].maxElapsedTime = millisecondsDuration(retry.MaxElapsedTime)
+		resp.Diagnostics.Append(diags...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	maxConcurrency := int64(multiDefaultMaxConcurrency)
+	if !model.MaxConcurrency.IsNull() {
+		maxConcurrency = model.MaxConcurrency.ValueInt64()
+	}
+
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+
+	for range min(maxConcurrency, int64(len(keys))) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range indexes {
+				results[i].response, results[i].diags = results[i].fetch(ctx, keys[i], urls[keys[i]], model.RequestHeaders)
+			}
+		}()
+	}
+
+	for i := range keys {
+		indexes <- i
+	}
+
+	close(indexes)
+	wg.Wait()
+
+	responses := make(map[string]multiResponseModel, len(keys))
+
+	for i, key := range keys {
+		resp.Diagnostics.Append(results[i].diags...)
+		responses[key] = results[i].response
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model.Responses, diags = types.MapValueFrom(ctx, types.ObjectType{AttrTypes: multiResponseAttrTypes}, responses)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model.ID = types.StringValue("http_multi")
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+// newMultiRetryClient returns the client of one of the requests of the
+// `http_multi` data source, using the provider configuration of its host.
+func newMultiRetryClient(ctx context.Context, providerData *providerData, model multiModelV0, retry retryModel) (*retryablehttp.Client, diag.Diagnostics) {
+	tr, diags := newTransport(providerData, model.CaCertificate, model.Insecure)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	retryClient := retryablehttp.NewClient()
+	retryClient.HTTPClient.Transport = roundTripper(providerData, tr)
+	retryClient.HTTPClient.Timeout = requestTimeout(providerData, model.RequestTimeout)
+	retryClient.Logger = levelledLogger{ctx}
+
+	opts := retryOptions{
+		method:                http.MethodGet,
+		decodeContentEncoding: true,
+		retryAfterHonored:     &atomic.Bool{},
+	}
+
+	if providerData != nil {
+		opts.retryBudget = providerData.retryBudget
+	}
+
+	diags.Append(configureRetry(ctx, retryClient, retry, opts)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return retryClient, diags
+}
+
+// fetch makes the request of the given key and returns its response.
+func (r *multiResult) fetch(ctx context.Context, key, requestURL string, requestHeaders types.Map) (multiResponseModel, diag.Diagnostics) {
+	providerData := r.providerData
+	retryClient := r.client
+
+	requestHeaders, diags := requestHeadersWithDefaults(providerData, requestHeaders)
+	if diags.HasError() {
+		return multiResponseModel{}, diags
+	}
+
+	// The `max_elapsed_time_ms` deadline applies to all attempts of the
+	// request.
+	if r.maxElapsedTime > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.maxElapsedTime)
+		defer cancel()
+	}
+
+	request, diags := newRequest(ctx, http.MethodGet, requestURL, nil, requestHeaders)
+	if diags.HasError() {
+		return multiResponseModel{}, diags
+	}
+
+	var attempts atomic.Int64
+
+	retryClient.RequestLogHook = func(_ retryablehttp.Logger, _ *http.Request, _ int) {
+		attempts.Add(1)
+	}
+	retryClient.ErrorHandler = retryErrorHandler(request)
+
+	retryRequest, err := retryablehttp.FromRequest(request)
+	if err != nil {
+		diags.AddError(
+			"Error creating request",
+			fmt.Sprintf("Error creating the request of %q: %s", key, err),
+		)
+		return multiResponseModel{}, diags
+	}
+
+	response, err := retryClient.Do(retryRequest)
+
+	if providerData != nil {
+		providerData.metrics.recordRetries(request.URL.Host, attempts.Load()-1)
+	}
+
+	if err != nil {
+		if errors.Is(err, errRetryBudgetExhausted) {
+			diags.AddError(
+				"Retry Budget Exhausted",
+				fmt.Sprintf("The request of %q was not retried, as the provider retry_budget of %d retries has been "+
+					"spent by earlier requests. This usually indicates a widespread outage.\n\nError making request: %s",
+					key, providerData.retryBudget.size, err),
+			)
+			return multiResponseModel{}, diags
+		}
+
+		diags.AddError(
+			"Error making request",
+			fmt.Sprintf("Error making the request of %q: %s\n\nError code: %s", key, err, requestErrorCode(err)),
+		)
+		return multiResponseModel{}, diags
+	}
+
+	defer response.Body.Close()
+
+	body, err := contentDecodingReader(response.Header, response.Body)
+	if err != nil {
+		diags.AddError(
+			"Error decoding response body",
+			fmt.Sprintf("The response body of %q could not be decoded according to its Content-Encoding header: %s", key, err),
+		)
+		return multiResponseModel{}, diags
+	}
+
+	responseBody, err := io.ReadAll(body)
+	if err != nil {
+		diags.AddError(
+			"Error reading response body",
+			fmt.Sprintf("Error reading the response body of %q: %s", key, err),
+		)
+		return multiResponseModel{}, diags
+	}
+
+	responseHeaders := make(map[string]string)
+	for k, v := range response.Header {
+		// Concatenate according to RFC9110 https://www.rfc-editor.org/rfc/rfc9110.html#section-5.2
+		responseHeaders[k] = strings.Join(v, ", ")
+	}
+
+	headers, diags := types.MapValueFrom(ctx, types.StringType, responseHeaders)
+	if diags.HasError() {
+		return multiResponseModel{}, diags
+	}
+
+	return multiResponseModel{
+		URL:             types.StringValue(requestURL),
+		StatusCode:      types.Int64Value(int64(response.StatusCode)),
+		ResponseHeaders: headers,
+		ResponseBody:    types.StringValue(string(responseBody)),
+	}, diags
+}
+
+// multiResult is the outcome of one of the requests of the `http_multi` data
+// source.
+type multiResult struct {
+	providerData   *providerData
+	client         *retryablehttp.Client
+	maxElapsedTime time.Duration
+	response       multiResponseModel
+	diags          diag.Diagnostics
+}
+
+type multiModelV0 struct {
+	ID             types.String `tfsdk:"id"`
+	URLs           types.Map    `tfsdk:"urls"`
+	RequestHeaders types.Map    `tfsdk:"request_headers"`
+	RequestTimeout types.Int64  `tfsdk:"request_timeout_ms"`
+	MaxConcurrency types.Int64  `tfsdk:"max_concurrency"`
+	CaCertificate  types.String `tfsdk:"ca_cert_pem"`
+	Insecure       types.Bool   `tfsdk:"insecure"`
+	Retry          types.Object `tfsdk:"retry"`
+	Responses      types.Map    `tfsdk:"responses"`
+}
+
+type multiResponseModel struct {
+	URL             types.String `tfsdk:"url"`
+	StatusCode      types.Int64  `tfsdk:"status_code"`
+	ResponseHeaders types.Map    `tfsdk:"response_headers"`
+	ResponseBody    types.String `tfsdk:"response_body"`
+}
+
+var multiResponseAttrTypes = map[string]attr.Type{
+	"url":              types.StringType,
+	"status_code":      types.Int64Type,
+	"response_headers": types.MapType{ElemType: types.StringType},
+	"response_body":    types.StringType,
+}
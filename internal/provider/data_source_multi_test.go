@@ -0,0 +1,192 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestMultiDataSource_200(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int64
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+
+		for {
+			current := maxInFlight.Load()
+			if n <= current || maxInFlight.CompareAndSwap(current, n) {
+				break
+			}
+		}
+
+		time.Sleep(50 * time.Millisecond)
+
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain")
+		w.Header().Add("X-Service", "a")
+		w.Header().Add("X-Service", "b")
+		_, _ = w.Write([]byte(strings.TrimPrefix(r.URL.Path, "/")))
+	}))
+	defer testServer.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http_multi" "http_test" {
+								urls = {
+									one     = "%[1]s/one"
+									two     = "%[1]s/two"
+									three   = "%[1]s/three"
+									four    = "%[1]s/four"
+									missing = "%[1]s/missing"
+								}
+
+								request_headers = {
+									Authorization = "Bearer token"
+								}
+
+								max_concurrency = 2
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http_multi.http_test", "responses.%", "5"),
+					resource.TestCheckResourceAttr("data.http_multi.http_test", "responses.one.url", testServer.URL+"/one"),
+					resource.TestCheckResourceAttr("data.http_multi.http_test", "responses.one.status_code", "200"),
+					resource.TestCheckResourceAttr("data.http_multi.http_test", "responses.one.response_body", "one"),
+					resource.TestCheckResourceAttr("data.http_multi.http_test", "responses.one.response_headers.X-Service", "a, b"),
+					resource.TestCheckResourceAttr("data.http_multi.http_test", "responses.four.response_body", "four"),
+					resource.TestCheckResourceAttr("data.http_multi.http_test", "responses.missing.status_code", "404"),
+					func(_ *terraform.State) error {
+						if maxInFlight.Load() > 2 {
+							return fmt.Errorf("expected at most 2 concurrent requests, got %d", maxInFlight.Load())
+						}
+
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestMultiDataSource_Retry(t *testing.T) {
+	var attempts atomic.Int64
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/flaky" && attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer testServer.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http_multi" "http_test" {
+								urls = {
+									flaky  = "%[1]s/flaky"
+									stable = "%[1]s/stable"
+								}
+
+								retry {
+									attempts     = 1
+									min_delay_ms = 1
+									max_delay_ms = 1
+								}
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http_multi.http_test", "responses.flaky.status_code", "200"),
+					resource.TestCheckResourceAttr("data.http_multi.http_test", "responses.stable.status_code", "200"),
+				),
+			},
+		},
+	})
+}
+
+func TestMultiDataSource_RetryWhileBodyMatches(t *testing.T) {
+	var attempts atomic.Int64
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) < 3 {
+			_, _ = w.Write([]byte(`{"status": "provisioning"}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"status": "ready"}`))
+	}))
+	defer testServer.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http_multi" "http_test" {
+								urls = {
+									status = "%s"
+								}
+
+								retry {
+									attempts           = 3
+									min_delay_ms       = 1
+									max_delay_ms       = 1
+									while_body_matches = "provisioning"
+								}
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http_multi.http_test", "responses.status.response_body", `{"status": "ready"}`),
+				),
+			},
+		},
+	})
+}
+
+func TestMultiDataSource_RequestError(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer testServer.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http_multi" "http_test" {
+								urls = {
+									ok          = "%s"
+									unreachable = "http://127.0.0.1:1"
+								}
+							}`, testServer.URL),
+				ExpectError: regexp.MustCompile(`Error\s+making\s+the\s+request\s+of\s+"unreachable"`),
+			},
+		},
+	})
+}
@@ -0,0 +1,304 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = (*robotsTxtDataSource)(nil)
+	_ datasource.DataSourceWithConfigure = (*robotsTxtDataSource)(nil)
+)
+
+func NewRobotsTxtDataSource() datasource.DataSource {
+	return &robotsTxtDataSource{}
+}
+
+type robotsTxtDataSource struct {
+	providerData *providerData
+}
+
+func (d *robotsTxtDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	data, diags := configureProviderData(req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+	d.providerData = data
+}
+
+func (d *robotsTxtDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_robots_txt"
+}
+
+func (d *robotsTxtDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `
+The ` + "`http_robots_txt`" + ` data source retrieves the ` + "`/robots.txt`" + ` document of a site and
+parses it according to [RFC 9309](https://datatracker.ietf.org/doc/html/rfc9309).
+
+Rules are only parsed when the server responds with a 2xx-range status code. Otherwise ` + "`groups`" + `
+and ` + "`sitemaps`" + ` are empty and ` + "`status_code`" + ` can be used to determine whether the
+document is unavailable (4xx-range) or unreachable (5xx-range).
+`,
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The URL of the robots.txt document.",
+				Computed:    true,
+			},
+
+			"url": schema.StringAttribute{
+				Description: "The URL of the site. The robots.txt document is requested from the root of this URL's origin. " +
+					"Supported schemes are `http` and `https`.",
+				Required: true,
+			},
+
+			"request_headers": schema.MapAttribute{
+				Description: "A map of request header field names and values.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+
+			"request_timeout_ms": schema.Int64Attribute{
+				Description: "The request timeout in milliseconds.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"ca_cert_pem": schema.StringAttribute{
+				Description: "Certificate data of the Certificate Authority (CA) " +
+					"in [PEM (RFC 1421)](https://datatracker.ietf.org/doc/html/rfc1421) format.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("insecure")),
+				},
+			},
+
+			"insecure": schema.BoolAttribute{
+				Description: "Disables verification of the server's certificate chain and hostname. Defaults to `false`",
+				Optional:    true,
+			},
+
+			"status_code": schema.Int64Attribute{
+				Description: "The HTTP response status code.",
+				Computed:    true,
+			},
+
+			"content": schema.StringAttribute{
+				Description: "The unparsed robots.txt document.",
+				Computed:    true,
+			},
+
+			"sitemaps": schema.ListAttribute{
+				Description: "The URLs of the `Sitemap` records in the document.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+
+			"groups": schema.ListAttribute{
+				Description: "The groups of rules in the document, in the order they appear. " +
+					"A `crawl_delay` is only set when the group contains a non-standard `crawl-delay` record.",
+				ElementType: types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"user_agents": types.ListType{ElemType: types.StringType},
+						"allow":       types.ListType{ElemType: types.StringType},
+						"disallow":    types.ListType{ElemType: types.StringType},
+						"crawl_delay": types.Float64Type,
+					},
+				},
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *robotsTxtDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	// Export the spans of the requests, if tracing is configured.
+	defer d.providerData.flushSpans(ctx)
+
+	var model robotsTxtModelV0
+	diags := req.Config.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	siteURL, err := url.Parse(model.URL.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("url"),
+			"Invalid URL",
+			fmt.Sprintf("The url value could not be parsed: %s", err),
+		)
+		return
+	}
+
+	robotsURL := siteURL.ResolveReference(&url.URL{Path: "/robots.txt"}).String()
+
+	providerData := d.providerData.forURL(robotsURL)
+
+	requestHeaders, diags := requestHeadersWithDefaults(providerData, model.RequestHeaders)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tr, diags := newTransport(providerData, model.CaCertificate, model.Insecure)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	response, bytes, diags := doGetRequest(ctx, roundTripper(providerData, tr), robotsURL, requestHeaders, requestTimeout(providerData, model.RequestTimeout))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	robots := robotsTxt{
+		groups:   []robotsTxtGroup{},
+		sitemaps: []string{},
+	}
+
+	if response.StatusCode >= 200 && response.StatusCode < 300 {
+		robots = parseRobotsTxt(string(bytes))
+	}
+
+	model.ID = types.StringValue(robotsURL)
+	model.StatusCode = types.Int64Value(int64(response.StatusCode))
+	model.Content = types.StringValue(string(bytes))
+	model.Sitemaps = robots.sitemaps
+	model.Groups = make([]robotsTxtGroupModel, 0, len(robots.groups))
+
+	for _, group := range robots.groups {
+		groupModel := robotsTxtGroupModel{
+			UserAgents: group.userAgents,
+			Allow:      group.allow,
+			Disallow:   group.disallow,
+			CrawlDelay: types.Float64Null(),
+		}
+
+		if group.crawlDelay != nil {
+			groupModel.CrawlDelay = types.Float64Value(*group.crawlDelay)
+		}
+
+		model.Groups = append(model.Groups, groupModel)
+	}
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+type robotsTxtModelV0 struct {
+	ID             types.String          `tfsdk:"id"`
+	URL            types.String          `tfsdk:"url"`
+	RequestHeaders types.Map             `tfsdk:"request_headers"`
+	RequestTimeout types.Int64           `tfsdk:"request_timeout_ms"`
+	CaCertificate  types.String          `tfsdk:"ca_cert_pem"`
+	Insecure       types.Bool            `tfsdk:"insecure"`
+	StatusCode     types.Int64           `tfsdk:"status_code"`
+	Content        types.String          `tfsdk:"content"`
+	Sitemaps       []string              `tfsdk:"sitemaps"`
+	Groups         []robotsTxtGroupModel `tfsdk:"groups"`
+}
+
+type robotsTxtGroupModel struct {
+	UserAgents []string      `tfsdk:"user_agents"`
+	Allow      []string      `tfsdk:"allow"`
+	Disallow   []string      `tfsdk:"disallow"`
+	CrawlDelay types.Float64 `tfsdk:"crawl_delay"`
+}
+
+type robotsTxt struct {
+	groups   []robotsTxtGroup
+	sitemaps []string
+}
+
+type robotsTxtGroup struct {
+	userAgents []string
+	allow      []string
+	disallow   []string
+	crawlDelay *float64
+}
+
+// parseRobotsTxt parses a robots.txt document as described in RFC 9309.
+// Unrecognized records are ignored. Rules which appear before the first
+// user-agent line do not belong to any group and are also ignored.
+func parseRobotsTxt(content string) robotsTxt {
+	result := robotsTxt{
+		groups:   []robotsTxtGroup{},
+		sitemaps: []string{},
+	}
+
+	var group *robotsTxtGroup
+	inUserAgentLines := false
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			if !inUserAgentLines {
+				result.groups = append(result.groups, robotsTxtGroup{
+					userAgents: []string{},
+					allow:      []string{},
+					disallow:   []string{},
+				})
+				group = &result.groups[len(result.groups)-1]
+				inUserAgentLines = true
+			}
+
+			group.userAgents = append(group.userAgents, value)
+		case "allow", "disallow", "crawl-delay":
+			inUserAgentLines = false
+
+			if group == nil {
+				continue
+			}
+
+			switch key {
+			case "allow":
+				group.allow = append(group.allow, value)
+			case "disallow":
+				group.disallow = append(group.disallow, value)
+			case "crawl-delay":
+				if delay, err := strconv.ParseFloat(value, 64); err == nil {
+					group.crawlDelay = &delay
+				}
+			}
+		case "sitemap":
+			result.sitemaps = append(result.sitemaps, value)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,91 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestRobotsTxtDataSource_200(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/robots.txt" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte(`# Example robots.txt
+User-agent: Googlebot
+User-Agent: bingbot
+Disallow: /private/ # not for crawlers
+Allow: /private/public.html
+Crawl-delay: 2.5
+
+user-agent: *
+disallow: /
+
+Sitemap: https://www.example.com/sitemap.xml
+`))
+	}))
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http_robots_txt" "test" {
+								url = "%s/some/page"
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http_robots_txt.test", "id", testServer.URL+"/robots.txt"),
+					resource.TestCheckResourceAttr("data.http_robots_txt.test", "status_code", "200"),
+					resource.TestCheckResourceAttr("data.http_robots_txt.test", "sitemaps.#", "1"),
+					resource.TestCheckResourceAttr("data.http_robots_txt.test", "sitemaps.0", "https://www.example.com/sitemap.xml"),
+					resource.TestCheckResourceAttr("data.http_robots_txt.test", "groups.#", "2"),
+					resource.TestCheckResourceAttr("data.http_robots_txt.test", "groups.0.user_agents.#", "2"),
+					resource.TestCheckResourceAttr("data.http_robots_txt.test", "groups.0.user_agents.0", "Googlebot"),
+					resource.TestCheckResourceAttr("data.http_robots_txt.test", "groups.0.user_agents.1", "bingbot"),
+					resource.TestCheckResourceAttr("data.http_robots_txt.test", "groups.0.disallow.0", "/private/"),
+					resource.TestCheckResourceAttr("data.http_robots_txt.test", "groups.0.allow.0", "/private/public.html"),
+					resource.TestCheckResourceAttr("data.http_robots_txt.test", "groups.0.crawl_delay", "2.5"),
+					resource.TestCheckResourceAttr("data.http_robots_txt.test", "groups.1.user_agents.0", "*"),
+					resource.TestCheckResourceAttr("data.http_robots_txt.test", "groups.1.disallow.0", "/"),
+					resource.TestCheckResourceAttr("data.http_robots_txt.test", "groups.1.allow.#", "0"),
+					resource.TestCheckNoResourceAttr("data.http_robots_txt.test", "groups.1.crawl_delay"),
+				),
+			},
+		},
+	})
+}
+
+func TestRobotsTxtDataSource_404(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte("User-agent: *\nDisallow: /\n"))
+	}))
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http_robots_txt" "test" {
+								url = "%s"
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http_robots_txt.test", "status_code", "404"),
+					resource.TestCheckResourceAttr("data.http_robots_txt.test", "groups.#", "0"),
+					resource.TestCheckResourceAttr("data.http_robots_txt.test", "sitemaps.#", "0"),
+				),
+			},
+		},
+	})
+}
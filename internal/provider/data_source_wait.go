@@ -0,0 +1,356 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ohler55/ojg/jp"
+)
+
+// Defaults for the `http_wait` data source.
+const (
+	waitDefaultIntervalMs = 5000
+	waitDefaultTimeoutMs  = 300000
+)
+
+var (
+	_ datasource.DataSource                   = (*waitDataSource)(nil)
+	_ datasource.DataSourceWithConfigure      = (*waitDataSource)(nil)
+	_ datasource.DataSourceWithValidateConfig = (*waitDataSource)(nil)
+)
+
+func NewWaitDataSource() datasource.DataSource {
+	return &waitDataSource{}
+}
+
+type waitDataSource struct {
+	providerData *providerData
+}
+
+func (d *waitDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	data, diags := configureProviderData(req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+	d.providerData = data
+}
+
+func (d *waitDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_wait"
+}
+
+func (d *waitDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `
+The ` + "`http_wait`" + ` data source polls a URL with GET requests until the endpoint is ready or
+the timeout elapses, in which case the read fails.
+
+The endpoint is ready when the response satisfies all of the configured conditions. By default, any
+response with a 2xx-range status code is ready. Requests which fail, for example because the
+connection is refused, are treated as not ready.
+`,
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The URL used for the request.",
+				Computed:    true,
+			},
+
+			"url": schema.StringAttribute{
+				Description: "The URL to poll. Supported schemes are `http` and `https`.",
+				Required:    true,
+			},
+
+			"request_headers": schema.MapAttribute{
+				Description: "A map of request header field names and values.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+
+			"request_timeout_ms": schema.Int64Attribute{
+				Description: "The timeout of each request in milliseconds.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"ca_cert_pem": schema.StringAttribute{
+				Description: "Certificate data of the Certificate Authority (CA) " +
+					"in [PEM (RFC 1421)](https://datatracker.ietf.org/doc/html/rfc1421) format.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("insecure")),
+				},
+			},
+
+			"insecure": schema.BoolAttribute{
+				Description: "Disables verification of the server's certificate chain and hostname. Defaults to `false`",
+				Optional:    true,
+			},
+
+			"interval_ms": schema.Int64Attribute{
+				Description: fmt.Sprintf("The delay between requests in milliseconds. Defaults to `%d`.", waitDefaultIntervalMs),
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"timeout_ms": schema.Int64Attribute{
+				Description: fmt.Sprintf("The maximum time to wait in milliseconds. Defaults to `%d`.", waitDefaultTimeoutMs),
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"status_codes": schema.ListAttribute{
+				Description: "A list of response status codes which indicate that the endpoint is ready. " +
+					"Defaults to any 2xx-range status code.",
+				ElementType: types.Int64Type,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueInt64sAre(int64validator.Between(100, 599)),
+				},
+			},
+
+			"body_regex": schema.StringAttribute{
+				Description: "A [regular expression](https://github.com/google/re2/wiki/Syntax) which the response " +
+					"body must match for the endpoint to be ready.",
+				Optional: true,
+			},
+
+			"jsonpath": schema.StringAttribute{
+				Description: "A JSONPath expression evaluated against the response body. The endpoint is ready when " +
+					"its result, formatted in the same way as the `http` data source's `response_jsonpath`, equals `jsonpath_equals`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("jsonpath_equals")),
+				},
+			},
+
+			"jsonpath_equals": schema.StringAttribute{
+				Description: "The result of `jsonpath` which indicates that the endpoint is ready.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("jsonpath")),
+				},
+			},
+
+			"status_code": schema.Int64Attribute{
+				Description: "The HTTP response status code of the final request.",
+				Computed:    true,
+			},
+
+			"response_body": schema.StringAttribute{
+				Description: "The response body of the final request.",
+				Computed:    true,
+			},
+
+			"attempts": schema.Int64Attribute{
+				Description: "The number of requests made.",
+				Computed:    true,
+			},
+
+			"waited_ms": schema.Int64Attribute{
+				Description: "The time spent waiting for the endpoint to be ready in milliseconds.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *waitDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var model waitModelV0
+	diags := req.Config.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !model.BodyRegex.IsNull() && !model.BodyRegex.IsUnknown() {
+		if _, err := regexp.Compile(model.BodyRegex.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("body_regex"),
+				"Invalid Regular Expression",
+				fmt.Sprintf("The regular expression could not be compiled: %s", err),
+			)
+		}
+	}
+
+	if !model.JSONPath.IsNull() && !model.JSONPath.IsUnknown() {
+		if _, err := jp.ParseString(model.JSONPath.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("jsonpath"),
+				"Invalid JSONPath Expression",
+				fmt.Sprintf("The JSONPath expression %q could not be parsed: %s", model.JSONPath.ValueString(), err),
+			)
+		}
+	}
+}
+
+func (d *waitDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	// Export the spans of the requests, if tracing is configured.
+	defer d.providerData.flushSpans(ctx)
+
+	var model waitModelV0
+	diags := req.Config.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	providerData := d.providerData.forURL(model.URL.ValueString())
+
+	requestHeaders, diags := requestHeadersWithDefaults(providerData, model.RequestHeaders)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tr, diags := newTransport(providerData, model.CaCertificate, model.Insecure)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	interval := time.Duration(waitDefaultIntervalMs) * time.Millisecond
+	if !model.Interval.IsNull() {
+		interval = time.Duration(model.Interval.ValueInt64()) * time.Millisecond
+	}
+
+	timeout := time.Duration(waitDefaultTimeoutMs) * time.Millisecond
+	if !model.Timeout.IsNull() {
+		timeout = time.Duration(model.Timeout.ValueInt64()) * time.Millisecond
+	}
+
+	// The conditions are evaluated in the same way as `result_validation`
+	// conditions of the `http` data source.
+	conditions := []resultValidationModel{
+		{
+			StatusCodes: model.StatusCodes,
+			Regex:       model.BodyRegex,
+		},
+		{
+			JSONPath: model.JSONPath,
+			Equals:   model.JSONPathEquals,
+		},
+	}
+
+	requestURL := model.URL.ValueString()
+	requestTimeout := requestTimeout(providerData, model.RequestTimeout)
+	started := time.Now()
+	deadline := started.Add(timeout)
+
+	// The deadline also applies to requests in progress, so that an endpoint
+	// which does not respond cannot block the read past the timeout.
+	waitCtx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+
+	var attempts int64
+
+	for {
+		attempts++
+
+		// The reason the endpoint is not ready, if any.
+		var reason string
+
+		response, body, requestDiags := doGetRequest(waitCtx, roundTripper(providerData, tr), requestURL, requestHeaders, requestTimeout)
+		if requestDiags.HasError() && ctx.Err() == nil && errors.Is(waitCtx.Err(), context.DeadlineExceeded) {
+			resp.Diagnostics.AddError(
+				"Timeout Waiting for Endpoint",
+				fmt.Sprintf("The endpoint %s was not ready after %d attempt(s) in %s.\n\n%s",
+					requestURL, attempts, time.Since(started).Round(time.Millisecond), requestDiags.Errors()[0].Detail()),
+			)
+			return
+		} else if requestDiags.HasError() {
+			reason = requestDiags.Errors()[0].Detail()
+		} else if model.StatusCodes.IsNull() && (response.StatusCode < 200 || response.StatusCode > 299) {
+			reason = fmt.Sprintf("The response status code is %d.", response.StatusCode)
+		} else {
+			for _, condition := range conditions {
+				reason, diags = condition.check(ctx, response, string(body))
+				resp.Diagnostics.Append(diags...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				if reason != "" {
+					break
+				}
+			}
+		}
+
+		if reason == "" {
+			model.ID = types.StringValue(requestURL)
+			model.StatusCode = types.Int64Value(int64(response.StatusCode))
+			model.ResponseBody = types.StringValue(string(body))
+			break
+		}
+
+		tflog.Debug(ctx, "Endpoint is not ready", map[string]interface{}{
+			"url":     requestURL,
+			"attempt": attempts,
+			"reason":  reason,
+		})
+
+		if time.Now().Add(interval).After(deadline) {
+			resp.Diagnostics.AddError(
+				"Timeout Waiting for Endpoint",
+				fmt.Sprintf("The endpoint %s was not ready after %d attempt(s) in %s.\n\n%s",
+					requestURL, attempts, time.Since(started).Round(time.Millisecond), reason),
+			)
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			resp.Diagnostics.AddError(
+				"Error waiting for endpoint",
+				fmt.Sprintf("Error waiting for endpoint %s: %s", requestURL, ctx.Err()),
+			)
+			return
+		case <-time.After(interval):
+		}
+	}
+
+	model.Attempts = types.Int64Value(attempts)
+	model.Waited = types.Int64Value(time.Since(started).Milliseconds())
+
+	diags = resp.State.Set(ctx, model)
+	resp.Diagnostics.Append(diags...)
+}
+
+type waitModelV0 struct {
+	ID             types.String `tfsdk:"id"`
+	URL            types.String `tfsdk:"url"`
+	RequestHeaders types.Map    `tfsdk:"request_headers"`
+	RequestTimeout types.Int64  `tfsdk:"request_timeout_ms"`
+	CaCertificate  types.String `tfsdk:"ca_cert_pem"`
+	Insecure       types.Bool   `tfsdk:"insecure"`
+	Interval       types.Int64  `tfsdk:"interval_ms"`
+	Timeout        types.Int64  `tfsdk:"timeout_ms"`
+	StatusCodes    types.List   `tfsdk:"status_codes"`
+	BodyRegex      types.String `tfsdk:"body_regex"`
+	JSONPath       types.String `tfsdk:"jsonpath"`
+	JSONPathEquals types.String `tfsdk:"jsonpath_equals"`
+	StatusCode     types.Int64  `tfsdk:"status_code"`
+	ResponseBody   types.String `tfsdk:"response_body"`
+	Attempts       types.Int64  `tfsdk:"attempts"`
+	Waited         types.Int64  `tfsdk:"waited_ms"`
+}
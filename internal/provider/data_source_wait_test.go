@@ -0,0 +1,148 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestWaitDataSource_Ready(t *testing.T) {
+	var requests atomic.Int32
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch n := requests.Add(1); {
+		case n == 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case n == 2:
+			_, _ = w.Write([]byte(`{"status": "starting"}`))
+		default:
+			_, _ = w.Write([]byte(`{"status": "healthy"}`))
+		}
+	}))
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http_wait" "test" {
+								url         = "%s"
+								interval_ms = 10
+								timeout_ms  = 5000
+
+								jsonpath        = "$.status"
+								jsonpath_equals = "healthy"
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http_wait.test", "id", testServer.URL),
+					resource.TestCheckResourceAttr("data.http_wait.test", "status_code", "200"),
+					resource.TestCheckResourceAttr("data.http_wait.test", "response_body", `{"status": "healthy"}`),
+					resource.TestCheckResourceAttrSet("data.http_wait.test", "attempts"),
+					resource.TestCheckResourceAttrSet("data.http_wait.test", "waited_ms"),
+				),
+			},
+		},
+	})
+}
+
+func TestWaitDataSource_StatusCodesAndBodyRegex(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte("status: ready"))
+	}))
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http_wait" "test" {
+								url          = "%s"
+								status_codes = [202]
+								body_regex   = "ready"
+							}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.http_wait.test", "status_code", "202"),
+					resource.TestCheckResourceAttr("data.http_wait.test", "attempts", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestWaitDataSource_Timeout(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("status: starting"))
+	}))
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http_wait" "test" {
+								url         = "%s"
+								interval_ms = 50
+								timeout_ms  = 200
+								body_regex  = "ready"
+							}`, testServer.URL),
+				ExpectError: regexp.MustCompile(`was\s+not\s+ready\s+after\s+[0-9]+\s+attempt\(s\)(.|\n)*"status:\s+starting"\s+does\s+not\s+match\s+"ready"`),
+			},
+		},
+	})
+}
+
+// TestWaitDataSource_Timeout_HangingEndpoint verifies that the timeout also
+// applies to a request in progress when no request timeout is configured.
+func TestWaitDataSource_Timeout_HangingEndpoint(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(10 * time.Second):
+		}
+	}))
+	defer testServer.Close()
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							data "http_wait" "test" {
+								url        = "%s"
+								timeout_ms = 200
+							}`, testServer.URL),
+				ExpectError: regexp.MustCompile(`Timeout\s+Waiting\s+for\s+Endpoint`),
+			},
+		},
+	})
+}
+
+func TestWaitDataSource_InvalidJSONPath(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+							data "http_wait" "test" {
+								url             = "http://localhost"
+								jsonpath        = "$["
+								jsonpath_equals = "healthy"
+							}`,
+				ExpectError: regexp.MustCompile(`Invalid\s+JSONPath\s+Expression`),
+			},
+		},
+	})
+}
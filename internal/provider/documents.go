@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// Document formats, configured via `split_documents`.
+const (
+	splitDocumentsYAML = "yaml"
+	splitDocumentsJSON = "json"
+)
+
+// yamlDocumentSeparator matches a YAML document start marker on its own
+// line, optionally followed by a comment.
+var yamlDocumentSeparator = regexp.MustCompile(`(?m)^---[ \t]*(#.*)?\r?$`)
+
+// splitYAMLDocuments splits a YAML stream into its documents. Documents
+// which only contain whitespace are omitted.
+func splitYAMLDocuments(body string) []string {
+	documents := []string{}
+
+	for _, document := range yamlDocumentSeparator.Split(body, -1) {
+		if strings.TrimSpace(document) == "" {
+			continue
+		}
+
+		documents = append(documents, strings.TrimLeft(document, "\r\n"))
+	}
+
+	return documents
+}
+
+// splitJSONDocuments splits a stream of concatenated JSON values, which may
+// be separated by whitespace (e.g., newline delimited JSON), into its values.
+func splitJSONDocuments(body []byte) ([]string, error) {
+	documents := []string{}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+
+	for {
+		var raw json.RawMessage
+
+		err := decoder.Decode(&raw)
+		if err == io.EOF {
+			return documents, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		documents = append(documents, string(raw))
+	}
+}
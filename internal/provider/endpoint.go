@@ -0,0 +1,37 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type providerEndpointModel struct {
+	Name    types.String `tfsdk:"name"`
+	BaseURL types.String `tfsdk:"base_url"`
+}
+
+// endpointURL returns the URL of a request to the named provider `endpoint`,
+// which is the given path appended to the base URL of the endpoint.
+func endpointURL(p *providerData, name, path string) (string, error) {
+	var baseURL string
+	var ok bool
+
+	if p != nil {
+		baseURL, ok = p.endpoints[name]
+	}
+
+	if !ok {
+		return "", fmt.Errorf("the endpoint %q is not configured in the provider", name)
+	}
+
+	if path == "" {
+		return baseURL, nil
+	}
+
+	return strings.TrimSuffix(baseURL, "/") + "/" + strings.TrimPrefix(path, "/"), nil
+}
@@ -0,0 +1,419 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = (*oauth2TokenEphemeralResource)(nil)
+	_ ephemeral.EphemeralResourceWithConfigure = (*oauth2TokenEphemeralResource)(nil)
+)
+
+// OAuth 2.0 grant types, configured via `grant_type`.
+const (
+	grantTypeClientCredentials = "client_credentials"
+	grantTypePassword          = "password"
+	grantTypeRefreshToken      = "refresh_token"
+)
+
+// Client authentication methods, configured via `client_auth`.
+const (
+	clientAuthBasic = "basic"
+	clientAuthBody  = "body"
+)
+
+func NewOAuth2TokenEphemeralResource() ephemeral.EphemeralResource {
+	return &oauth2TokenEphemeralResource{}
+}
+
+type oauth2TokenEphemeralResource struct {
+	providerData *providerData
+}
+
+func (e *oauth2TokenEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	data, diags := configureProviderData(req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+	e.providerData = data
+}
+
+func (e *oauth2TokenEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_oauth2_token"
+}
+
+func (e *oauth2TokenEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `
+The ` + "`http_oauth2_token`" + ` ephemeral resource requests an access token from the token endpoint of an
+OAuth 2.0 authorization server, according to [RFC 6749](https://datatracker.ietf.org/doc/html/rfc6749),
+without storing it in the plan or state.
+
+A new token is requested whenever the ephemeral resource is opened, i.e. during each plan and apply, so
+tokens which have expired since an earlier run are never used.
+`,
+
+		Attributes: map[string]schema.Attribute{
+			"token_url": schema.StringAttribute{
+				Description: "The URL of the token endpoint. Supported schemes are `http` and `https`.",
+				Required:    true,
+			},
+
+			"grant_type": schema.StringAttribute{
+				Description: "The grant type, one of `client_credentials`, `password` or `refresh_token`. " +
+					"Defaults to `client_credentials`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(grantTypeClientCredentials, grantTypePassword, grantTypeRefreshToken),
+				},
+			},
+
+			"client_id": schema.StringAttribute{
+				Description: "The client identifier.",
+				Required:    true,
+			},
+
+			"client_secret": schema.StringAttribute{
+				Description: "The client secret. Public clients do not have a secret.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+
+			"client_auth": schema.StringAttribute{
+				Description: "How the client authenticates with the authorization server, either `basic` for " +
+					"HTTP Basic authentication or `body` for sending the client credentials as parameters of " +
+					"the request body. Defaults to `basic`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(clientAuthBasic, clientAuthBody),
+				},
+			},
+
+			"username": schema.StringAttribute{
+				Description: "The username of the resource owner, which is required by the `password` grant type.",
+				Optional:    true,
+			},
+
+			"password": schema.StringAttribute{
+				Description: "The password of the resource owner, which is required by the `password` grant type.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+
+			"refresh_token": schema.StringAttribute{
+				Description: "The refresh token, which is required by the `refresh_token` grant type.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+
+			"scopes": schema.ListAttribute{
+				Description: "The scopes of the access request.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+
+			"endpoint_params": schema.MapAttribute{
+				Description: "Additional parameters of the request body, such as `audience`.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+
+			"request_timeout_ms": schema.Int64Attribute{
+				Description: "The request timeout in milliseconds.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"ca_cert_pem": schema.StringAttribute{
+				Description: "Certificate data of the Certificate Authority (CA) " +
+					"in [PEM (RFC 1421)](https://datatracker.ietf.org/doc/html/rfc1421) format.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("insecure")),
+				},
+			},
+
+			"insecure": schema.BoolAttribute{
+				Description: "Disables verification of the server's certificate chain and hostname. Defaults to `false`",
+				Optional:    true,
+			},
+
+			"access_token": schema.StringAttribute{
+				Description: "The access token.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+
+			"token_type": schema.StringAttribute{
+				Description: "The type of the access token, such as `Bearer`.",
+				Computed:    true,
+			},
+
+			"expires_at": schema.StringAttribute{
+				Description: "The time at which the access token expires, in RFC 3339 format, or `null` if the " +
+					"authorization server did not specify its lifetime.",
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (e *oauth2TokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	// Export the spans of the requests, if tracing is configured.
+	defer e.providerData.flushSpans(ctx)
+
+	var model oauth2TokenModelV0
+	diags := req.Config.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	grantType := model.GrantType.ValueString()
+	if grantType == "" {
+		grantType = grantTypeClientCredentials
+	}
+
+	params := url.Values{}
+	params.Set("grant_type", grantType)
+
+	switch grantType {
+	case grantTypePassword:
+		for _, required := range []struct {
+			name  string
+			value types.String
+		}{
+			{"username", model.Username},
+			{"password", model.Password},
+		} {
+			if required.value.IsNull() {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(required.name),
+					"Missing Attribute Configuration",
+					fmt.Sprintf("The %s attribute is required by the password grant type.", required.name),
+				)
+			}
+		}
+
+		params.Set("username", model.Username.ValueString())
+		params.Set("password", model.Password.ValueString())
+	case grantTypeRefreshToken:
+		if model.RefreshToken.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("refresh_token"),
+				"Missing Attribute Configuration",
+				"The refresh_token attribute is required by the refresh_token grant type.",
+			)
+		}
+
+		params.Set("refresh_token", model.RefreshToken.ValueString())
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !model.Scopes.IsNull() {
+		var scopes []string
+
+		resp.Diagnostics.Append(model.Scopes.ElementsAs(ctx, &scopes, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		params.Set("scope", strings.Join(scopes, " "))
+	}
+
+	if !model.EndpointParams.IsNull() {
+		var endpointParams map[string]string
+
+		resp.Diagnostics.Append(model.EndpointParams.ElementsAs(ctx, &endpointParams, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		for name, value := range endpointParams {
+			params.Set(name, value)
+		}
+	}
+
+	clientAuth := model.ClientAuth.ValueString()
+
+	if clientAuth == clientAuthBody {
+		params.Set("client_id", model.ClientID.ValueString())
+
+		if !model.ClientSecret.IsNull() {
+			params.Set("client_secret", model.ClientSecret.ValueString())
+		}
+	}
+
+	tokenURL := model.TokenURL.ValueString()
+	providerData := e.providerData.forURL(tokenURL)
+
+	tr, diags := newTransport(providerData, model.CaCertificate, model.Insecure)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := &http.Client{
+		Transport: roundTripper(providerData, tr),
+		Timeout:   requestTimeout(providerData, model.RequestTimeout),
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(params.Encode()))
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("token_url"),
+			"Error creating request",
+			fmt.Sprintf("Error creating request: %s", err),
+		)
+		return
+	}
+
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+
+	// The client credentials are form-urlencoded before being used for
+	// Basic authentication, as required by RFC 6749, section 2.3.1.
+	if clientAuth != clientAuthBody {
+		request.SetBasicAuth(url.QueryEscape(model.ClientID.ValueString()), url.QueryEscape(model.ClientSecret.ValueString()))
+	}
+
+	issued := time.Now()
+
+	response, err := client.Do(request)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error making request",
+			fmt.Sprintf("Error making request: %s\n\nError code: %s", err, requestErrorCode(err)),
+		)
+		return
+	}
+
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading response body",
+			fmt.Sprintf("Error reading response body: %s", err),
+		)
+		return
+	}
+
+	token, err := parseOAuth2TokenResponse(response, body)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Requesting Token",
+			fmt.Sprintf("The token endpoint %s did not issue an access token: %s", request.URL.Redacted(), err),
+		)
+		return
+	}
+
+	model.AccessToken = types.StringValue(token.AccessToken)
+	model.TokenType = types.StringValue(token.TokenType)
+	model.ExpiresAt = types.StringNull()
+
+	if token.ExpiresIn > 0 {
+		model.ExpiresAt = types.StringValue(issued.Add(time.Duration(token.ExpiresIn) * time.Second).UTC().Format(time.RFC3339))
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, model)...)
+}
+
+// oauth2TokenResponse is a successful response of a token endpoint, as
+// defined by RFC 6749, section 5.1.
+type oauth2TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"-"`
+}
+
+// parseOAuth2TokenResponse returns the token of a response of a token
+// endpoint, or an error describing the error response of RFC 6749, section
+// 5.2.
+func parseOAuth2TokenResponse(response *http.Response, body []byte) (oauth2TokenResponse, error) {
+	var token struct {
+		oauth2TokenResponse
+
+		// Some authorization servers send the lifetime as a string.
+		ExpiresIn json.RawMessage `json:"expires_in"`
+
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+
+	if err := json.Unmarshal(body, &token); err != nil {
+		return oauth2TokenResponse{}, fmt.Errorf("the response with status %q could not be parsed as JSON: %w", response.Status, err)
+	}
+
+	if token.Error != "" {
+		if token.ErrorDescription != "" {
+			return oauth2TokenResponse{}, fmt.Errorf("%s: %s", token.Error, token.ErrorDescription)
+		}
+
+		return oauth2TokenResponse{}, fmt.Errorf("%s", token.Error)
+	}
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return oauth2TokenResponse{}, fmt.Errorf("unexpected response status %q", response.Status)
+	}
+
+	if token.AccessToken == "" {
+		return oauth2TokenResponse{}, fmt.Errorf("the response does not contain an access_token")
+	}
+
+	if len(token.ExpiresIn) > 0 {
+		expiresIn, err := strconv.ParseInt(strings.Trim(string(token.ExpiresIn), `"`), 10, 64)
+		if err != nil {
+			return oauth2TokenResponse{}, fmt.Errorf("the expires_in value %s is not a number of seconds", token.ExpiresIn)
+		}
+
+		token.oauth2TokenResponse.ExpiresIn = expiresIn
+	}
+
+	return token.oauth2TokenResponse, nil
+}
+
+type oauth2TokenModelV0 struct {
+	TokenURL       types.String `tfsdk:"token_url"`
+	GrantType      types.String `tfsdk:"grant_type"`
+	ClientID       types.String `tfsdk:"client_id"`
+	ClientSecret   types.String `tfsdk:"client_secret"`
+	ClientAuth     types.String `tfsdk:"client_auth"`
+	Username       types.String `tfsdk:"username"`
+	Password       types.String `tfsdk:"password"`
+	RefreshToken   types.String `tfsdk:"refresh_token"`
+	Scopes         types.List   `tfsdk:"scopes"`
+	EndpointParams types.Map    `tfsdk:"endpoint_params"`
+	RequestTimeout types.Int64  `tfsdk:"request_timeout_ms"`
+	CaCertificate  types.String `tfsdk:"ca_cert_pem"`
+	Insecure       types.Bool   `tfsdk:"insecure"`
+	AccessToken    types.String `tfsdk:"access_token"`
+	TokenType      types.String `tfsdk:"token_type"`
+	ExpiresAt      types.String `tfsdk:"expires_at"`
+}
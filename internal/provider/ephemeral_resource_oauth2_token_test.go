@@ -0,0 +1,217 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestOAuth2TokenEphemeralResource_ClientCredentials(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientID, clientSecret, ok := r.BasicAuth()
+		if r.Method != http.MethodPost || !ok || clientID != "client%3A1" || clientSecret != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error": "invalid_client"}`))
+			return
+		}
+
+		if r.PostFormValue("grant_type") != "client_credentials" || r.PostFormValue("scope") != "read write" ||
+			r.PostFormValue("audience") != "https://api.example.com" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error": "invalid_request", "error_description": "unexpected parameters"}`))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token": "token-1", "token_type": "Bearer", "expires_in": "3600"}`))
+	}))
+	defer testServer.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		ProtoV6ProviderFactories: protoV6EchoProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							ephemeral "http_oauth2_token" "test" {
+								token_url     = "%s/token"
+								client_id     = "client:1"
+								client_secret = "secret"
+								scopes        = ["read", "write"]
+
+								endpoint_params = {
+									audience = "https://api.example.com"
+								}
+							}
+
+							provider "echo" {
+								data = ephemeral.http_oauth2_token.test
+							}
+
+							resource "echo" "test" {}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("echo.test", "data.access_token", "token-1"),
+					resource.TestCheckResourceAttr("echo.test", "data.token_type", "Bearer"),
+					resource.TestMatchResourceAttr("echo.test", "data.expires_at", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}Z$`)),
+				),
+			},
+		},
+	})
+}
+
+func TestOAuth2TokenEphemeralResource_Password(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, _, ok := r.BasicAuth(); ok {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if r.PostFormValue("grant_type") != "password" || r.PostFormValue("client_id") != "client" ||
+			r.PostFormValue("username") != "user" || r.PostFormValue("password") != "pass" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error": "invalid_grant"}`))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token": "token-2", "token_type": "Bearer"}`))
+	}))
+	defer testServer.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		ProtoV6ProviderFactories: protoV6EchoProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							ephemeral "http_oauth2_token" "test" {
+								token_url   = "%s/token"
+								grant_type  = "password"
+								client_id   = "client"
+								client_auth = "body"
+							}
+
+							provider "echo" {
+								data = ephemeral.http_oauth2_token.test
+							}
+
+							resource "echo" "test" {}`, testServer.URL),
+				ExpectError: regexp.MustCompile(`The\s+username\s+attribute\s+is\s+required\s+by\s+the\s+password\s+grant\s+type`),
+			},
+			{
+				Config: fmt.Sprintf(`
+							ephemeral "http_oauth2_token" "test" {
+								token_url   = "%s/token"
+								grant_type  = "password"
+								client_id   = "client"
+								client_auth = "body"
+								username    = "user"
+								password    = "pass"
+							}
+
+							provider "echo" {
+								data = ephemeral.http_oauth2_token.test
+							}
+
+							resource "echo" "test" {}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("echo.test", "data.access_token", "token-2"),
+					resource.TestCheckNoResourceAttr("echo.test", "data.expires_at"),
+				),
+			},
+		},
+	})
+}
+
+// TestOAuth2TokenEphemeralResource_NewTokenPerOpen verifies that a new token
+// is requested whenever the ephemeral resource is opened, i.e. during both
+// plan and apply, instead of reusing a token which may have expired.
+func TestOAuth2TokenEphemeralResource_NewTokenPerOpen(t *testing.T) {
+	var tokenCount atomic.Int64
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count := tokenCount.Add(1)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token": "token-%d", "token_type": "Bearer", "expires_in": 60}`, count)
+	}))
+	defer testServer.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		ProtoV6ProviderFactories: protoV6EchoProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							ephemeral "http_oauth2_token" "test" {
+								token_url = "%s/token"
+								client_id = "client"
+							}
+
+							provider "echo" {
+								data = ephemeral.http_oauth2_token.test
+							}
+
+							resource "echo" "test" {}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("echo.test", "data.access_token", regexp.MustCompile(`^token-\d+$`)),
+				),
+			},
+		},
+	})
+
+	if tokenCount.Load() < 2 {
+		t.Errorf("expected a token to be requested during both plan and apply, got %d requests", tokenCount.Load())
+	}
+}
+
+func TestOAuth2TokenEphemeralResource_ErrorResponse(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error": "invalid_client", "error_description": "Client authentication failed"}`))
+	}))
+	defer testServer.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		ProtoV6ProviderFactories: protoV6EchoProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							ephemeral "http_oauth2_token" "test" {
+								token_url = "%s/token"
+								client_id = "client"
+							}
+
+							provider "echo" {
+								data = ephemeral.http_oauth2_token.test
+							}
+
+							resource "echo" "test" {}`, testServer.URL),
+				ExpectError: regexp.MustCompile(`invalid_client:\s+Client\s+authentication\s+failed`),
+			},
+		},
+	})
+}
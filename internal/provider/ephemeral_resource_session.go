@@ -0,0 +1,519 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ ephemeral.EphemeralResource              = (*sessionEphemeralResource)(nil)
+	_ ephemeral.EphemeralResourceWithConfigure = (*sessionEphemeralResource)(nil)
+	_ ephemeral.EphemeralResourceWithClose     = (*sessionEphemeralResource)(nil)
+)
+
+// sessionPrivateKey is the key of the private data of the http_session
+// ephemeral resource, which holds what is needed to log out on Close.
+const sessionPrivateKey = "logout"
+
+func NewSessionEphemeralResource() ephemeral.EphemeralResource {
+	return &sessionEphemeralResource{}
+}
+
+type sessionEphemeralResource struct {
+	providerData *providerData
+}
+
+func (e *sessionEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	data, diags := configureProviderData(req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+	e.providerData = data
+}
+
+func (e *sessionEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_session"
+}
+
+func (e *sessionEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: `
+The ` + "`http_session`" + ` ephemeral resource logs in to a web application or appliance and captures the
+session cookies, response headers and tokens of the login response, so that they can be used by other
+requests, without storing them in the plan or state.
+
+If ` + "`logout_url`" + ` is configured, the session is logged out when Terraform closes the ephemeral
+resource, once it is no longer needed.
+`,
+
+		Attributes: map[string]schema.Attribute{
+			"login_url": schema.StringAttribute{
+				Description: "The URL of the login request. Supported schemes are `http` and `https`.",
+				Required:    true,
+			},
+
+			"method": schema.StringAttribute{
+				Description: "The HTTP method of the login request. Defaults to `POST`.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(http.MethodGet, http.MethodPost, http.MethodPut),
+				},
+			},
+
+			"form_data": schema.MapAttribute{
+				Description: "The fields of an `application/x-www-form-urlencoded` login request body, such as " +
+					"the username and password.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+				Validators: []validator.Map{
+					mapvalidator.ConflictsWith(path.MatchRoot("json_body")),
+				},
+			},
+
+			"json_body": schema.StringAttribute{
+				Description: "The JSON body of an `application/json` login request.",
+				Optional:    true,
+				Sensitive:   true,
+			},
+
+			"request_headers": schema.MapAttribute{
+				Description: "A map of request header field names and values of the login request.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+
+			"capture_headers": schema.ListAttribute{
+				Description: "The names of the response headers of the login request which are captured in " +
+					"`headers`, such as `X-CSRF-Token`.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+
+			"token_jsonpath": schema.StringAttribute{
+				Description: "A JSONPath expression, such as `$.csrfToken`, which is evaluated against the JSON " +
+					"response body of the login request to capture `token`.",
+				Optional: true,
+			},
+
+			"logout_url": schema.StringAttribute{
+				Description: "The URL of a request which ends the session when the ephemeral resource is closed. " +
+					"The request includes the session cookies and the captured headers.",
+				Optional: true,
+			},
+
+			"logout_method": schema.StringAttribute{
+				Description: "The HTTP method of the logout request. Defaults to `POST`.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(http.MethodGet, http.MethodPost, http.MethodDelete),
+					stringvalidator.AlsoRequires(path.MatchRoot("logout_url")),
+				},
+			},
+
+			"request_timeout_ms": schema.Int64Attribute{
+				Description: "The timeout of the login and logout requests in milliseconds.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"ca_cert_pem": schema.StringAttribute{
+				Description: "Certificate data of the Certificate Authority (CA) " +
+					"in [PEM (RFC 1421)](https://datatracker.ietf.org/doc/html/rfc1421) format.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("insecure")),
+				},
+			},
+
+			"insecure": schema.BoolAttribute{
+				Description: "Disables verification of the server's certificate chain and hostname. Defaults to `false`",
+				Optional:    true,
+			},
+
+			"status_code": schema.Int64Attribute{
+				Description: "The HTTP response status code of the login request.",
+				Computed:    true,
+			},
+
+			"cookies": schema.MapAttribute{
+				Description: "The session cookies set by the login response, including redirects, by name.",
+				ElementType: types.StringType,
+				Computed:    true,
+				Sensitive:   true,
+			},
+
+			"cookie_header": schema.StringAttribute{
+				Description: "The session cookies as the value of a `Cookie` request header, such as " +
+					"`session=abc; csrf=def`.",
+				Computed:  true,
+				Sensitive: true,
+			},
+
+			"headers": schema.MapAttribute{
+				Description: "The values of the `capture_headers` of the login response, by name.",
+				ElementType: types.StringType,
+				Computed:    true,
+				Sensitive:   true,
+			},
+
+			"token": schema.StringAttribute{
+				Description: "The result of `token_jsonpath`, or `null` if it did not match anything.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+		},
+	}
+}
+
+func (e *sessionEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	// Export the spans of the requests, if tracing is configured.
+	defer e.providerData.flushSpans(ctx)
+
+	var model sessionModelV0
+	diags := req.Config.Get(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	loginURL := model.LoginURL.ValueString()
+
+	method := model.Method.ValueString()
+	if method == "" {
+		method = http.MethodPost
+	}
+
+	var body io.Reader
+	var contentType string
+
+	switch {
+	case !model.FormData.IsNull():
+		var formData map[string]string
+
+		resp.Diagnostics.Append(model.FormData.ElementsAs(ctx, &formData, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		values := url.Values{}
+		for name, value := range formData {
+			values.Set(name, value)
+		}
+
+		body = strings.NewReader(values.Encode())
+		contentType = "application/x-www-form-urlencoded"
+	case !model.JSONBody.IsNull():
+		if !json.Valid([]byte(model.JSONBody.ValueString())) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("json_body"),
+				"Invalid JSON Body",
+				"The json_body value is not valid JSON.",
+			)
+			return
+		}
+
+		body = strings.NewReader(model.JSONBody.ValueString())
+		contentType = "application/json"
+	}
+
+	providerData := e.providerData.forURL(loginURL)
+
+	requestHeaders, diags := requestHeadersWithDefaults(providerData, model.RequestHeaders)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tr, diags := newTransport(providerData, model.CaCertificate, model.Insecure)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The cookie jar keeps the cookies set by redirects of the login
+	// request as well.
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating cookie jar",
+			fmt.Sprintf("Error creating cookie jar: %s", err),
+		)
+		return
+	}
+
+	client := &http.Client{
+		Transport: roundTripper(providerData, tr),
+		Timeout:   requestTimeout(providerData, model.RequestTimeout),
+		Jar:       jar,
+	}
+
+	request, diags := newRequest(ctx, method, loginURL, body, requestHeaders)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A configured Content-Type request header takes precedence.
+	if contentType != "" && request.Header.Get("Content-Type") == "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error making request",
+			fmt.Sprintf("Error making request: %s\n\nError code: %s", err, requestErrorCode(err)),
+		)
+		return
+	}
+
+	defer response.Body.Close()
+
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading response body",
+			fmt.Sprintf("Error reading response body: %s", err),
+		)
+		return
+	}
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		detail := fmt.Sprintf("The login request to %s has the status %q.", request.URL.Redacted(), response.Status)
+
+		if details, ok := responseProblemDetails(response.Header, responseBody); ok {
+			detail += "\n\n" + details.String()
+		}
+
+		resp.Diagnostics.AddError("Login Failed", detail)
+		return
+	}
+
+	cookies := make(map[string]string)
+	cookiePairs := make([]string, 0)
+
+	for _, cookie := range jar.Cookies(request.URL) {
+		cookies[cookie.Name] = cookie.Value
+		cookiePairs = append(cookiePairs, cookie.Name+"="+cookie.Value)
+	}
+
+	var captureHeaders []string
+
+	resp.Diagnostics.Append(model.CaptureHeaders.ElementsAs(ctx, &captureHeaders, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	captured := make(map[string]string, len(captureHeaders))
+
+	for _, name := range captureHeaders {
+		if values := response.Header.Values(name); len(values) > 0 {
+			captured[name] = strings.Join(values, ", ")
+		}
+	}
+
+	model.Token = types.StringNull()
+
+	if !model.TokenJSONPath.IsNull() {
+		document, err := parseJSONPathDocument(responseBody)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error evaluating JSONPath expressions",
+				fmt.Sprintf("The response body of the login request could not be parsed as JSON: %s", err),
+			)
+			return
+		}
+
+		token, ok, err := jsonPathString(document, model.TokenJSONPath.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("token_jsonpath"),
+				"Invalid JSONPath Expression",
+				fmt.Sprintf("The JSONPath expression could not be evaluated: %s", err),
+			)
+			return
+		}
+
+		if ok {
+			model.Token = types.StringValue(token)
+		}
+	}
+
+	model.StatusCode = types.Int64Value(int64(response.StatusCode))
+	model.CookieHeader = types.StringValue(strings.Join(cookiePairs, "; "))
+
+	model.Cookies, diags = types.MapValueFrom(ctx, types.StringType, cookies)
+	resp.Diagnostics.Append(diags...)
+
+	model.Headers, diags = types.MapValueFrom(ctx, types.StringType, captured)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !model.LogoutURL.IsNull() {
+		logout := sessionLogout{
+			URL:            model.LogoutURL.ValueString(),
+			Method:         model.LogoutMethod.ValueString(),
+			CookieHeader:   model.CookieHeader.ValueString(),
+			Headers:        captured,
+			CACertPEM:      model.CaCertificate.ValueStringPointer(),
+			Insecure:       model.Insecure.ValueBoolPointer(),
+			RequestTimeout: model.RequestTimeout.ValueInt64(),
+		}
+
+		if logout.Method == "" {
+			logout.Method = http.MethodPost
+		}
+
+		private, err := json.Marshal(logout)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error storing session",
+				fmt.Sprintf("Error encoding the logout request: %s", err),
+			)
+			return
+		}
+
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, sessionPrivateKey, private)...)
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, model)...)
+}
+
+func (e *sessionEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	// Export the spans of the requests, if tracing is configured.
+	defer e.providerData.flushSpans(ctx)
+
+	private, diags := req.Private.GetKey(ctx, sessionPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || private == nil {
+		return
+	}
+
+	var logout sessionLogout
+
+	if err := json.Unmarshal(private, &logout); err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading session",
+			fmt.Sprintf("Error decoding the logout request: %s", err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(logout.do(ctx, e.providerData)...)
+}
+
+// sessionLogout is the logout request of a session, which is kept in the
+// private data of the ephemeral resource, as the configuration is not
+// available on Close.
+type sessionLogout struct {
+	URL            string            `json:"url"`
+	Method         string            `json:"method"`
+	CookieHeader   string            `json:"cookie_header"`
+	Headers        map[string]string `json:"headers"`
+	CACertPEM      *string           `json:"ca_cert_pem"`
+	Insecure       *bool             `json:"insecure"`
+	RequestTimeout int64             `json:"request_timeout_ms"`
+}
+
+// do makes the logout request. A response with a 4xx or 5xx status code only
+// results in a warning, as the session may have expired already.
+func (l sessionLogout) do(ctx context.Context, p *providerData) diag.Diagnostics {
+	providerData := p.forURL(l.URL)
+
+	tr, diags := newTransport(providerData, types.StringPointerValue(l.CACertPEM), types.BoolPointerValue(l.Insecure))
+	if diags.HasError() {
+		return diags
+	}
+
+	client := &http.Client{
+		Transport: roundTripper(providerData, tr),
+		Timeout:   requestTimeout(providerData, types.Int64Value(l.RequestTimeout)),
+	}
+
+	request, err := http.NewRequestWithContext(ctx, l.Method, l.URL, nil)
+	if err != nil {
+		diags.AddError(
+			"Error creating request",
+			fmt.Sprintf("Error creating request: %s", err),
+		)
+		return diags
+	}
+
+	for name, value := range l.Headers {
+		request.Header.Set(name, value)
+	}
+
+	if l.CookieHeader != "" {
+		request.Header.Set("Cookie", l.CookieHeader)
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		diags.AddError(
+			"Error making request",
+			fmt.Sprintf("Error making logout request: %s\n\nError code: %s", err, requestErrorCode(err)),
+		)
+		return diags
+	}
+
+	defer response.Body.Close()
+
+	_, _ = io.Copy(io.Discard, response.Body)
+
+	if response.StatusCode >= http.StatusBadRequest {
+		diags.AddWarning(
+			"Logout Failed",
+			fmt.Sprintf("The logout request to %s has the status %q. The session may remain active until it expires.",
+				request.URL.Redacted(), response.Status),
+		)
+	}
+
+	return diags
+}
+
+type sessionModelV0 struct {
+	LoginURL       types.String `tfsdk:"login_url"`
+	Method         types.String `tfsdk:"method"`
+	FormData       types.Map    `tfsdk:"form_data"`
+	JSONBody       types.String `tfsdk:"json_body"`
+	RequestHeaders types.Map    `tfsdk:"request_headers"`
+	CaptureHeaders types.List   `tfsdk:"capture_headers"`
+	TokenJSONPath  types.String `tfsdk:"token_jsonpath"`
+	LogoutURL      types.String `tfsdk:"logout_url"`
+	LogoutMethod   types.String `tfsdk:"logout_method"`
+	RequestTimeout types.Int64  `tfsdk:"request_timeout_ms"`
+	CaCertificate  types.String `tfsdk:"ca_cert_pem"`
+	Insecure       types.Bool   `tfsdk:"insecure"`
+	StatusCode     types.Int64  `tfsdk:"status_code"`
+	Cookies        types.Map    `tfsdk:"cookies"`
+	CookieHeader   types.String `tfsdk:"cookie_header"`
+	Headers        types.Map    `tfsdk:"headers"`
+	Token          types.String `tfsdk:"token"`
+}
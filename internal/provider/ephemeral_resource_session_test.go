@@ -0,0 +1,122 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestSessionEphemeralResource_Login(t *testing.T) {
+	var logoutCount atomic.Int64
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/login":
+			if r.Method != http.MethodPost || r.PostFormValue("username") != "admin" || r.PostFormValue("password") != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc", Path: "/"})
+			w.Header().Set("X-CSRF-Token", "def")
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"user": {"token": "ghi"}}`))
+		case "/logout":
+			cookie, err := r.Cookie("session")
+			if r.Method != http.MethodDelete || err != nil || cookie.Value != "abc" || r.Header.Get("X-CSRF-Token") != "def" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			logoutCount.Add(1)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer testServer.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		ProtoV6ProviderFactories: protoV6EchoProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							ephemeral "http_session" "test" {
+								login_url = "%[1]s/login"
+
+								form_data = {
+									username = "admin"
+									password = "secret"
+								}
+
+								capture_headers = ["X-CSRF-Token"]
+								token_jsonpath  = "$.user.token"
+
+								logout_url    = "%[1]s/logout"
+								logout_method = "DELETE"
+							}
+
+							provider "echo" {
+								data = ephemeral.http_session.test
+							}
+
+							resource "echo" "test" {}`, testServer.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("echo.test", "data.status_code", "200"),
+					resource.TestCheckResourceAttr("echo.test", "data.cookies.session", "abc"),
+					resource.TestCheckResourceAttr("echo.test", "data.cookie_header", "session=abc"),
+					resource.TestCheckResourceAttr("echo.test", "data.headers.X-CSRF-Token", "def"),
+					resource.TestCheckResourceAttr("echo.test", "data.token", "ghi"),
+				),
+			},
+		},
+	})
+
+	if logoutCount.Load() == 0 {
+		t.Error("expected the session to be logged out")
+	}
+}
+
+func TestSessionEphemeralResource_LoginFailed(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer testServer.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		ProtoV6ProviderFactories: protoV6EchoProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+							ephemeral "http_session" "test" {
+								login_url = "%s/login"
+								json_body = jsonencode({ username = "admin", password = "wrong" })
+							}
+
+							provider "echo" {
+								data = ephemeral.http_session.test
+							}
+
+							resource "echo" "test" {}`, testServer.URL),
+				ExpectError: regexp.MustCompile(`has\s+the\s+status\s+"401\s+Unauthorized"`),
+			},
+		},
+	})
+}
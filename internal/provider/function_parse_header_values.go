@@ -0,0 +1,116 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = (*parseHeaderValuesFunction)(nil)
+
+func NewParseHeaderValuesFunction() function.Function {
+	return &parseHeaderValuesFunction{}
+}
+
+type parseHeaderValuesFunction struct{}
+
+func (f *parseHeaderValuesFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_header_values"
+}
+
+func (f *parseHeaderValuesFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Split a comma-separated header value into its individual values",
+		MarkdownDescription: "Splits a header value containing a comma-separated list, such as the values in the " +
+			"`response_headers` attribute of the `http` data source, into its individual values as described in " +
+			"[RFC 9110](https://www.rfc-editor.org/rfc/rfc9110.html#section-5.6.1). Commas within quoted strings " +
+			"and within the `Expires` attribute of cookies are not treated as separators. Each value is trimmed of " +
+			"surrounding whitespace and empty values are omitted.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "value",
+				MarkdownDescription: "The header value to split.",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *parseHeaderValuesFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &value))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, parseHeaderValues(value)))
+}
+
+// parseHeaderValues splits a comma-separated header value into its
+// individual values. Commas are not treated as separators inside quoted
+// strings, or when they follow the day name in a cookie `Expires` attribute
+// (e.g. "Expires=Wed, 21 Oct 2015 07:28:00 GMT").
+func parseHeaderValues(value string) []string {
+	values := []string{}
+
+	var current strings.Builder
+	inQuotes := false
+	escaped := false
+
+	appendCurrent := func() {
+		if v := strings.TrimSpace(current.String()); v != "" {
+			values = append(values, v)
+		}
+		current.Reset()
+	}
+
+	for _, r := range value {
+		switch {
+		case escaped:
+			escaped = false
+		case inQuotes && r == '\\':
+			escaped = true
+		case r == '"':
+			inQuotes = !inQuotes
+		case !inQuotes && r == ',' && !isCookieExpiresDay(current.String()):
+			appendCurrent()
+			continue
+		}
+
+		current.WriteRune(r)
+	}
+
+	appendCurrent()
+
+	return values
+}
+
+// isCookieExpiresDay returns true if the given value ends with the day name
+// of a cookie `Expires` attribute, which is followed by a comma.
+func isCookieExpiresDay(value string) bool {
+	i := strings.LastIndex(value, ";")
+	if i < 0 {
+		return false
+	}
+
+	name, day, found := strings.Cut(value[i+1:], "=")
+	if !found || !strings.EqualFold(strings.TrimSpace(name), "expires") {
+		return false
+	}
+
+	switch strings.ToLower(strings.TrimSpace(day)) {
+	case "mon", "tue", "wed", "thu", "fri", "sat", "sun",
+		"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday":
+		return true
+	}
+
+	return false
+}
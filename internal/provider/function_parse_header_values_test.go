@@ -0,0 +1,59 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestParseHeaderValuesFunction(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "cache_control" {
+					value = provider::http::parse_header_values("no-cache, no-store,, max-age=0")
+				}
+
+				output "quoted" {
+					value = provider::http::parse_header_values("W/\"a,b\", \"c\\\"d,e\"")
+				}
+
+				output "cookies" {
+					value = provider::http::parse_header_values("id=a3fWa; Expires=Wed, 21 Oct 2015 07:28:00 GMT; Secure, lang=en-US; Path=/")
+				}
+
+				output "empty" {
+					value = provider::http::parse_header_values("")
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("cache_control", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.StringExact("no-cache"),
+						knownvalue.StringExact("no-store"),
+						knownvalue.StringExact("max-age=0"),
+					})),
+					statecheck.ExpectKnownOutputValue("quoted", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.StringExact(`W/"a,b"`),
+						knownvalue.StringExact(`"c\"d,e"`),
+					})),
+					statecheck.ExpectKnownOutputValue("cookies", knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.StringExact("id=a3fWa; Expires=Wed, 21 Oct 2015 07:28:00 GMT; Secure"),
+						knownvalue.StringExact("lang=en-US; Path=/"),
+					})),
+					statecheck.ExpectKnownOutputValue("empty", knownvalue.ListExact([]knownvalue.Check{})),
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,121 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var (
+	_ function.Function = (*urldecodeFunction)(nil)
+	_ function.Function = (*urldecodeQueryFunction)(nil)
+)
+
+func NewURLDecodeFunction() function.Function {
+	return &urldecodeFunction{}
+}
+
+type urldecodeFunction struct{}
+
+func (f *urldecodeFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "urldecode"
+}
+
+func (f *urldecodeFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Decode a percent-encoded URL component",
+		MarkdownDescription: "Decodes the percent-encoded octets of a URL component, such as a path segment, as " +
+			"described in [RFC 3986](https://www.rfc-editor.org/rfc/rfc3986.html#section-2.1). A `+` is not " +
+			"decoded as a space; use `urldecode_query` for query strings and form request bodies. The function " +
+			"fails if the value contains a malformed escape sequence or does not decode to valid UTF-8.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "value",
+				MarkdownDescription: "The string to decode.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *urldecodeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &value))
+	if resp.Error != nil {
+		return
+	}
+
+	decoded, err := urlDecode(url.PathUnescape, value)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid encoded value: "+err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, decoded))
+}
+
+func NewURLDecodeQueryFunction() function.Function {
+	return &urldecodeQueryFunction{}
+}
+
+type urldecodeQueryFunction struct{}
+
+func (f *urldecodeQueryFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "urldecode_query"
+}
+
+func (f *urldecodeQueryFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Decode a string of a URL query string",
+		MarkdownDescription: "Decodes a string in the `application/x-www-form-urlencoded` format described in the " +
+			"[URL Standard](https://url.spec.whatwg.org/#application/x-www-form-urlencoded), such as the name or " +
+			"value of a query parameter, in which a `+` is decoded as a space. The function fails if the value " +
+			"contains a malformed escape sequence or does not decode to valid UTF-8.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "value",
+				MarkdownDescription: "The string to decode.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *urldecodeQueryFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &value))
+	if resp.Error != nil {
+		return
+	}
+
+	decoded, err := urlDecode(url.QueryUnescape, value)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid encoded value: "+err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, decoded))
+}
+
+// urlDecode decodes the value with the given unescape function and checks
+// that the result is valid UTF-8, as Terraform strings must be.
+func urlDecode(unescape func(string) (string, error), value string) (string, error) {
+	decoded, err := unescape(value)
+	if err != nil {
+		return "", err
+	}
+
+	if !utf8.ValidString(decoded) {
+		return "", errors.New("the decoded value is not valid UTF-8")
+	}
+
+	return decoded, nil
+}
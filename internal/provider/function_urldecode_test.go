@@ -0,0 +1,98 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestURLDecodeFunction(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "reserved" {
+					value = provider::http::urldecode("a%20b%2Fc+d")
+				}
+
+				output "unicode" {
+					value = provider::http::urldecode("caf%C3%A9%20%e2%98%95")
+				}
+
+				output "round_trip" {
+					value = provider::http::urldecode(provider::http::urlencode("a b/c?d=e&f+g"))
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("reserved", knownvalue.StringExact("a b/c+d")),
+					statecheck.ExpectKnownOutputValue("unicode", knownvalue.StringExact("café ☕")),
+					statecheck.ExpectKnownOutputValue("round_trip", knownvalue.StringExact("a b/c?d=e&f+g")),
+				},
+			},
+		},
+	})
+}
+
+func TestURLDecodeFunction_Invalid(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "test" {
+					value = provider::http::urldecode("100%")
+				}
+				`,
+				ExpectError: regexp.MustCompile(`Invalid\s+encoded\s+value:\s+invalid\s+URL\s+escape\s+"%"`),
+			},
+			{
+				Config: `
+				output "test" {
+					value = provider::http::urldecode("%FF")
+				}
+				`,
+				ExpectError: regexp.MustCompile(`not\s+valid\s+UTF-8`),
+			},
+		},
+	})
+}
+
+func TestURLDecodeQueryFunction(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "reserved" {
+					value = provider::http::urldecode_query("a+b%2Fc%2Bd%20e")
+				}
+
+				output "round_trip" {
+					value = provider::http::urldecode_query(provider::http::urlencode_query("a b/c?d=e&f+g"))
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("reserved", knownvalue.StringExact("a b/c+d e")),
+					statecheck.ExpectKnownOutputValue("round_trip", knownvalue.StringExact("a b/c?d=e&f+g")),
+				},
+			},
+		},
+	})
+}
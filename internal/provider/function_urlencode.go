@@ -0,0 +1,117 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var (
+	_ function.Function = (*urlencodeFunction)(nil)
+	_ function.Function = (*urlencodeQueryFunction)(nil)
+)
+
+func NewURLEncodeFunction() function.Function {
+	return &urlencodeFunction{}
+}
+
+type urlencodeFunction struct{}
+
+func (f *urlencodeFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "urlencode"
+}
+
+func (f *urlencodeFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Percent-encode a string for use as a URL component",
+		MarkdownDescription: "Percent-encodes each UTF-8 byte of a string except the unreserved characters " +
+			"(`A`-`Z`, `a`-`z`, `0`-`9`, `-`, `.`, `_` and `~`), as described in " +
+			"[RFC 3986](https://www.rfc-editor.org/rfc/rfc3986.html#section-2.3), so that the result can be used " +
+			"as any component of a URL, such as a path segment or a query parameter name or value. Unlike the " +
+			"built-in `urlencode` function, which encodes query strings, spaces are encoded as `%20` rather than `+`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "value",
+				MarkdownDescription: "The string to encode.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *urlencodeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &value))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, urlEncodeComponent(value)))
+}
+
+func NewURLEncodeQueryFunction() function.Function {
+	return &urlencodeQueryFunction{}
+}
+
+type urlencodeQueryFunction struct{}
+
+func (f *urlencodeQueryFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "urlencode_query"
+}
+
+func (f *urlencodeQueryFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Percent-encode a string for use in a URL query string",
+		MarkdownDescription: "Encodes a string in the `application/x-www-form-urlencoded` format described in the " +
+			"[URL Standard](https://url.spec.whatwg.org/#application/x-www-form-urlencoded), which is used for the " +
+			"names and values of query parameters and of form request bodies. Spaces are encoded as `+` and all " +
+			"other characters except `A`-`Z`, `a`-`z`, `0`-`9`, `-`, `.`, `_` and `~` are percent-encoded.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "value",
+				MarkdownDescription: "The string to encode.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *urlencodeQueryFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &value))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, url.QueryEscape(value)))
+}
+
+// urlEncodeComponent percent-encodes all bytes of the value except the
+// unreserved characters of RFC 3986, section 2.3.
+func urlEncodeComponent(value string) string {
+	const hex = "0123456789ABCDEF"
+
+	var encoded strings.Builder
+
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '-' || c == '.' || c == '_' || c == '~' {
+			encoded.WriteByte(c)
+			continue
+		}
+
+		encoded.WriteByte('%')
+		encoded.WriteByte(hex[c>>4])
+		encoded.WriteByte(hex[c&15])
+	}
+
+	return encoded.String()
+}
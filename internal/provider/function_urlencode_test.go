@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestURLEncodeFunction(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "reserved" {
+					value = provider::http::urlencode("a b/c?d=e&f+g:h@i!*'()")
+				}
+
+				output "unreserved" {
+					value = provider::http::urlencode("AZaz09-._~")
+				}
+
+				output "unicode" {
+					value = provider::http::urlencode("café ☕")
+				}
+
+				output "empty" {
+					value = provider::http::urlencode("")
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("reserved", knownvalue.StringExact("a%20b%2Fc%3Fd%3De%26f%2Bg%3Ah%40i%21%2A%27%28%29")),
+					statecheck.ExpectKnownOutputValue("unreserved", knownvalue.StringExact("AZaz09-._~")),
+					statecheck.ExpectKnownOutputValue("unicode", knownvalue.StringExact("caf%C3%A9%20%E2%98%95")),
+					statecheck.ExpectKnownOutputValue("empty", knownvalue.StringExact("")),
+				},
+			},
+		},
+	})
+}
+
+func TestURLEncodeQueryFunction(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV5ProviderFactories: protoV5ProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "reserved" {
+					value = provider::http::urlencode_query("a b/c?d=e&f+g~")
+				}
+
+				output "unicode" {
+					value = provider::http::urlencode_query("café")
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("reserved", knownvalue.StringExact("a+b%2Fc%3Fd%3De%26f%2Bg~")),
+					statecheck.ExpectKnownOutputValue("unicode", knownvalue.StringExact("caf%C3%A9")),
+				},
+			},
+		},
+	})
+}
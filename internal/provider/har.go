@@ -0,0 +1,223 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"runtime/debug"
+	"sort"
+	"time"
+	"unicode/utf8"
+)
+
+// The types below are the subset of the HAR 1.2 format
+// (http://www.softwareishard.com/blog/har-12-spec/) used to record a single
+// request and response.
+
+type harDocument struct {
+	Log harLog `json:"log"`
+}
+
+type harLog struct {
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Entries []harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+type harTimings struct {
+	Blocked float64 `json:"blocked"`
+	DNS     float64 `json:"dns"`
+	Connect float64 `json:"connect"`
+	SSL     float64 `json:"ssl"`
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// harExchange returns a HAR document recording the given request and
+// response. The response body is nil if it was not stored.
+func harExchange(request *http.Request, requestBody *string, response *http.Response, responseBody []byte, timings requestTimings) ([]byte, error) {
+	creatorVersion := "unknown"
+	if info, ok := debug.ReadBuildInfo(); ok {
+		creatorVersion = info.Main.Version
+	}
+
+	harRequest := harRequest{
+		Method:      request.Method,
+		URL:         request.URL.String(),
+		HTTPVersion: request.Proto,
+		Cookies:     []harNameValue{},
+		Headers:     harHeaders(request.Header),
+		QueryString: []harNameValue{},
+		HeadersSize: -1,
+		BodySize:    0,
+	}
+
+	for name, values := range request.URL.Query() {
+		for _, value := range values {
+			harRequest.QueryString = append(harRequest.QueryString, harNameValue{Name: name, Value: value})
+		}
+	}
+
+	sort.SliceStable(harRequest.QueryString, func(i, j int) bool {
+		return harRequest.QueryString[i].Name < harRequest.QueryString[j].Name
+	})
+
+	if requestBody != nil {
+		harRequest.BodySize = len(*requestBody)
+		harRequest.PostData = &harPostData{
+			MimeType: request.Header.Get("Content-Type"),
+			Text:     *requestBody,
+		}
+	}
+
+	harResponse := harResponse{
+		Status:      response.StatusCode,
+		StatusText:  http.StatusText(response.StatusCode),
+		HTTPVersion: response.Proto,
+		Cookies:     []harNameValue{},
+		Headers:     harHeaders(response.Header),
+		Content: harContent{
+			MimeType: response.Header.Get("Content-Type"),
+		},
+		RedirectURL: response.Header.Get("Location"),
+		HeadersSize: -1,
+		BodySize:    -1,
+	}
+
+	if responseBody != nil {
+		harResponse.BodySize = len(responseBody)
+		harResponse.Content.Size = len(responseBody)
+
+		if utf8.Valid(responseBody) {
+			harResponse.Content.Text = string(responseBody)
+		} else {
+			harResponse.Content.Text = base64.StdEncoding.EncodeToString(responseBody)
+			harResponse.Content.Encoding = "base64"
+		}
+	}
+
+	milliseconds := func(d time.Duration) float64 {
+		return float64(d.Microseconds()) / 1000
+	}
+
+	harTimings := harTimings{
+		Blocked: -1,
+		DNS:     -1,
+		Connect: -1,
+		SSL:     -1,
+		Send:    0,
+		Wait:    milliseconds(max(timings.timeToFirstByte-timings.dns-timings.connect, 0)),
+		Receive: milliseconds(max(timings.total-timings.timeToFirstByte, 0)),
+	}
+
+	// Connection phases are -1 if an existing connection was reused.
+	if timings.dns > 0 {
+		harTimings.DNS = milliseconds(timings.dns)
+	}
+
+	if timings.connect > 0 {
+		// The HAR connect time includes the TLS handshake.
+		harTimings.Connect = milliseconds(timings.connect + timings.tls)
+	}
+
+	if timings.tls > 0 {
+		harTimings.SSL = milliseconds(timings.tls)
+	}
+
+	document := harDocument{
+		Log: harLog{
+			Version: "1.2",
+			Creator: harCreator{
+				Name:    "terraform-provider-http",
+				Version: creatorVersion,
+			},
+			Entries: []harEntry{
+				{
+					StartedDateTime: timings.started.Format(time.RFC3339Nano),
+					Time:            milliseconds(timings.total),
+					Request:         harRequest,
+					Response:        harResponse,
+					Timings:         harTimings,
+				},
+			},
+		},
+	}
+
+	return json.MarshalIndent(document, "", "  ")
+}
+
+// harHeaders returns the headers as HAR name and value pairs, sorted by name.
+func harHeaders(header http.Header) []harNameValue {
+	headers := []harNameValue{}
+
+	for name, values := range header {
+		for _, value := range values {
+			headers = append(headers, harNameValue{Name: name, Value: value})
+		}
+	}
+
+	sort.SliceStable(headers, func(i, j int) bool {
+		return headers[i].Name < headers[j].Name
+	})
+
+	return headers
+}
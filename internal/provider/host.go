@@ -0,0 +1,223 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/time/rate"
+)
+
+type providerHostModel struct {
+	Name                  types.String `tfsdk:"name"`
+	RequestHeaders        types.Map    `tfsdk:"request_headers"`
+	Username              types.String `tfsdk:"username"`
+	Password              types.String `tfsdk:"password"`
+	BearerToken           types.String `tfsdk:"bearer_token"`
+	CaCertificate         types.String `tfsdk:"ca_cert_pem"`
+	Insecure              types.Bool   `tfsdk:"insecure"`
+	RequestTimeout        types.Int64  `tfsdk:"request_timeout_ms"`
+	ConnectTimeout        types.Int64  `tfsdk:"connect_timeout_ms"`
+	TLSHandshakeTimeout   types.Int64  `tfsdk:"tls_handshake_timeout_ms"`
+	ResponseHeaderTimeout types.Int64  `tfsdk:"response_header_timeout_ms"`
+	RateLimit             types.Object `tfsdk:"rate_limit"`
+}
+
+// hostConfig is the configuration of a provider `host` block, which applies
+// to requests whose URL host matches the name.
+type hostConfig struct {
+	// name is a hostname, or a wildcard such as *.example.com which matches
+	// any subdomain.
+	name string
+
+	// requestHeaders are the default request headers, including the
+	// Authorization header if credentials are configured.
+	requestHeaders map[string]string
+
+	caCertPEM             string
+	insecure              *bool
+	requestTimeout        time.Duration
+	connectTimeout        time.Duration
+	tlsHandshakeTimeout   time.Duration
+	responseHeaderTimeout time.Duration
+
+	// rateLimiter limits the rate of requests to the matching hosts instead
+	// of the provider-level limit, if set.
+	rateLimiter *rate.Limiter
+}
+
+// newHostConfig returns the configuration of a provider `host` block.
+func newHostConfig(ctx context.Context, model providerHostModel) (hostConfig, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	host := hostConfig{
+		name:                  strings.ToLower(model.Name.ValueString()),
+		requestHeaders:        map[string]string{},
+		caCertPEM:             model.CaCertificate.ValueString(),
+		requestTimeout:        millisecondsDuration(model.RequestTimeout),
+		connectTimeout:        millisecondsDuration(model.ConnectTimeout),
+		tlsHandshakeTimeout:   millisecondsDuration(model.TLSHandshakeTimeout),
+		responseHeaderTimeout: millisecondsDuration(model.ResponseHeaderTimeout),
+	}
+
+	if !model.RequestHeaders.IsNull() {
+		diags.Append(model.RequestHeaders.ElementsAs(ctx, &host.requestHeaders, false)...)
+		if diags.HasError() {
+			return host, diags
+		}
+	}
+
+	rateLimiter, rateLimitDiags := newRateLimiter(ctx, model.RateLimit)
+	diags.Append(rateLimitDiags...)
+	if diags.HasError() {
+		return host, diags
+	}
+
+	host.rateLimiter = rateLimiter
+
+	if !model.Insecure.IsNull() {
+		insecure := model.Insecure.ValueBool()
+		host.insecure = &insecure
+	}
+
+	var authorization string
+
+	switch {
+	case !model.BearerToken.IsNull():
+		authorization = "Bearer " + model.BearerToken.ValueString()
+	case !model.Username.IsNull():
+		credentials := model.Username.ValueString() + ":" + model.Password.ValueString()
+		authorization = "Basic " + base64.StdEncoding.EncodeToString([]byte(credentials))
+	}
+
+	if authorization != "" && !hasHeader(host.requestHeaders, "Authorization") {
+		host.requestHeaders["Authorization"] = authorization
+	}
+
+	return host, diags
+}
+
+// matches returns true if the host block applies to the given URL host,
+// without port.
+func (h hostConfig) matches(hostname string) bool {
+	hostname = strings.ToLower(hostname)
+
+	if suffix, ok := strings.CutPrefix(h.name, "*."); ok {
+		return strings.HasSuffix(hostname, "."+suffix)
+	}
+
+	return hostname == h.name
+}
+
+// forURL returns the provider configuration which applies to requests to the
+// given URL, with the settings of the first matching `host` block taking
+// precedence over the provider defaults.
+func (p *providerData) forURL(requestURL string) *providerData {
+	if p == nil || len(p.hosts) == 0 {
+		return p
+	}
+
+	u, err := url.Parse(requestURL)
+	if err != nil {
+		return p
+	}
+
+	for _, host := range p.hosts {
+		if !host.matches(u.Hostname()) {
+			continue
+		}
+
+		data := *p
+		data.requestHeaders = make(map[string]string, len(p.requestHeaders)+len(host.requestHeaders))
+
+		// The headers of the host take precedence over the defaults of the
+		// environment.
+		for name, value := range p.requestHeaders {
+			if !hasHeader(host.requestHeaders, name) {
+				data.requestHeaders[name] = value
+			}
+		}
+
+		for name, value := range host.requestHeaders {
+			data.requestHeaders[name] = value
+		}
+
+		if host.caCertPEM != "" {
+			data.caCertPEM = host.caCertPEM
+		}
+
+		if host.insecure != nil {
+			data.insecure = host.insecure
+		}
+
+		if host.requestTimeout > 0 {
+			data.requestTimeout = host.requestTimeout
+		}
+
+		if host.connectTimeout > 0 {
+			data.connectTimeout = host.connectTimeout
+		}
+
+		if host.tlsHandshakeTimeout > 0 {
+			data.tlsHandshakeTimeout = host.tlsHandshakeTimeout
+		}
+
+		if host.responseHeaderTimeout > 0 {
+			data.responseHeaderTimeout = host.responseHeaderTimeout
+		}
+
+		if host.rateLimiter != nil {
+			data.rateLimiter = host.rateLimiter
+		}
+
+		return &data
+	}
+
+	return p
+}
+
+// requestHeadersWithDefaults returns the configured `request_headers` merged
+// with the default request headers of the provider configuration. Configured
+// headers take precedence, regardless of the case of their names.
+func requestHeadersWithDefaults(p *providerData, requestHeaders types.Map) (types.Map, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if p == nil || len(p.requestHeaders) == 0 || requestHeaders.IsUnknown() {
+		return requestHeaders, diags
+	}
+
+	elements := make(map[string]attr.Value, len(p.requestHeaders)+len(requestHeaders.Elements()))
+
+	for name, value := range requestHeaders.Elements() {
+		elements[name] = value
+	}
+
+	for name, value := range p.requestHeaders {
+		if !hasHeader(elements, name) {
+			elements[name] = types.StringValue(value)
+		}
+	}
+
+	return types.MapValue(types.StringType, elements)
+}
+
+// hasHeader returns true if the map contains the header name, compared
+// case-insensitively.
+func hasHeader[V any](headers map[string]V, name string) bool {
+	for key := range headers {
+		if http.CanonicalHeaderKey(key) == http.CanonicalHeaderKey(name) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,121 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// decodeJSON decodes a JSON document, preserving numbers as json.Number.
+func decodeJSON(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// jsonDynamicValue returns the decoded JSON document as a dynamic value, or a
+// null dynamic value if the data is not a valid JSON document.
+func jsonDynamicValue(ctx context.Context, data []byte) (types.Dynamic, diag.Diagnostics) {
+	if !json.Valid(data) {
+		return types.DynamicNull(), nil
+	}
+
+	v, err := decodeJSON(data)
+	if err != nil {
+		return types.DynamicNull(), nil
+	}
+
+	value, diags := jsonAttrValue(ctx, v)
+	if diags.HasError() {
+		return types.DynamicNull(), diags
+	}
+
+	return types.DynamicValue(value), diags
+}
+
+// jsonAttrValue converts a value decoded by decodeJSON into the equivalent
+// Terraform value, following the same type mapping as the jsondecode function:
+// objects become objects, arrays become tuples and null becomes a dynamic null.
+func jsonAttrValue(ctx context.Context, v interface{}) (attr.Value, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	switch v := v.(type) {
+	case nil:
+		return types.DynamicNull(), diags
+	case bool:
+		return types.BoolValue(v), diags
+	case string:
+		return types.StringValue(v), diags
+	case json.Number:
+		f, _, err := big.ParseFloat(v.String(), 10, 512, big.ToNearestEven)
+		if err != nil {
+			diags.AddError(
+				"Error converting JSON value",
+				fmt.Sprintf("Error converting JSON number %q: %s", v.String(), err),
+			)
+			return nil, diags
+		}
+
+		return types.NumberValue(f), diags
+	case []interface{}:
+		elemTypes := make([]attr.Type, 0, len(v))
+		elems := make([]attr.Value, 0, len(v))
+
+		for _, elem := range v {
+			elemValue, elemDiags := jsonAttrValue(ctx, elem)
+			diags.Append(elemDiags...)
+			if diags.HasError() {
+				return nil, diags
+			}
+
+			elemTypes = append(elemTypes, elemValue.Type(ctx))
+			elems = append(elems, elemValue)
+		}
+
+		tuple, tupleDiags := types.TupleValue(elemTypes, elems)
+		diags.Append(tupleDiags...)
+
+		return tuple, diags
+	case map[string]interface{}:
+		attrTypes := make(map[string]attr.Type, len(v))
+		attrs := make(map[string]attr.Value, len(v))
+
+		for key, elem := range v {
+			elemValue, elemDiags := jsonAttrValue(ctx, elem)
+			diags.Append(elemDiags...)
+			if diags.HasError() {
+				return nil, diags
+			}
+
+			attrTypes[key] = elemValue.Type(ctx)
+			attrs[key] = elemValue
+		}
+
+		object, objectDiags := types.ObjectValue(attrTypes, attrs)
+		diags.Append(objectDiags...)
+
+		return object, diags
+	}
+
+	diags.AddError(
+		"Error converting JSON value",
+		fmt.Sprintf("Unexpected JSON value type: %T", v),
+	)
+
+	return nil, diags
+}
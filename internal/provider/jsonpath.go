@@ -0,0 +1,110 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/ohler55/ojg/jp"
+	"github.com/ohler55/ojg/oj"
+)
+
+// parseJSONPathDocument parses a JSON document into the generic
+// representation used when evaluating JSONPath expressions.
+func parseJSONPathDocument(data []byte) (interface{}, error) {
+	return oj.Parse(data)
+}
+
+// jsonPathString evaluates the JSONPath expression against the document and
+// returns the result as a string. A single string result is returned as is,
+// any other single result is JSON encoded and multiple results are returned
+// as a JSON encoded array. The boolean result is false if nothing matched.
+func jsonPathString(document interface{}, expression string) (string, bool, error) {
+	x, err := jp.ParseString(expression)
+	if err != nil {
+		return "", false, err
+	}
+
+	return jsonPathResultString(x.Get(document))
+}
+
+// jsonPathResultString returns the results of a JSONPath expression as a
+// string, as described for jsonPathString.
+func jsonPathResultString(results []interface{}) (string, bool, error) {
+	switch len(results) {
+	case 0:
+		return "", false, nil
+	case 1:
+		if s, ok := results[0].(string); ok {
+			return s, true, nil
+		}
+
+		b, err := json.Marshal(results[0])
+		if err != nil {
+			return "", false, err
+		}
+
+		return string(b), true, nil
+	}
+
+	b, err := json.Marshal(results)
+	if err != nil {
+		return "", false, err
+	}
+
+	return string(b), true, nil
+}
+
+// streamJSONArray decodes a JSON array from the reader one element at a time
+// and evaluates the JSONPath expression against a single-element array
+// containing each element, so that only the results are kept in memory.
+// Elements for which the expression does not match anything are omitted.
+func streamJSONArray(r io.Reader, expression string) ([]string, error) {
+	x, err := jp.ParseString(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(r)
+
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return nil, errors.New("the response body is not a JSON array")
+	}
+
+	results := []string{}
+
+	for decoder.More() {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return nil, err
+		}
+
+		element, err := parseJSONPathDocument(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		result, ok, err := jsonPathResultString(x.Get([]interface{}{element}))
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			results = append(results, result)
+		}
+	}
+
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
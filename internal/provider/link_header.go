@@ -0,0 +1,88 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"net/url"
+	"strings"
+)
+
+// parseLinkHeaders parses the values of Link headers as described in
+// RFC 8288 and returns a map of relation types to target URLs. Relative
+// targets are resolved against the given base URL. If multiple links have
+// the same relation type, the first one is used.
+func parseLinkHeaders(values []string, base *url.URL) map[string]string {
+	links := make(map[string]string)
+
+	for _, value := range values {
+		for _, link := range splitLinkHeader(value) {
+			target, params, found := strings.Cut(link, ">")
+			if !found || !strings.HasPrefix(target, "<") {
+				continue
+			}
+
+			targetURL, err := url.Parse(strings.TrimSpace(target[1:]))
+			if err != nil {
+				continue
+			}
+
+			if base != nil {
+				targetURL = base.ResolveReference(targetURL)
+			}
+
+			for _, param := range strings.Split(params, ";") {
+				name, paramValue, found := strings.Cut(param, "=")
+				if !found || !strings.EqualFold(strings.TrimSpace(name), "rel") {
+					continue
+				}
+
+				// The rel parameter may contain multiple space-separated relation types.
+				for _, rel := range strings.Fields(strings.Trim(strings.TrimSpace(paramValue), `"`)) {
+					rel = strings.ToLower(rel)
+
+					if _, ok := links[rel]; !ok {
+						links[rel] = targetURL.String()
+					}
+				}
+
+				break
+			}
+		}
+	}
+
+	return links
+}
+
+// splitLinkHeader splits a Link header value into its links. Commas within
+// the target URL or quoted strings are not treated as separators.
+func splitLinkHeader(value string) []string {
+	var links []string
+
+	var current strings.Builder
+	inTarget := false
+	inQuotes := false
+
+	for _, r := range value {
+		switch {
+		case inQuotes:
+			inQuotes = r != '"'
+		case inTarget:
+			inTarget = r != '>'
+		case r == '<':
+			inTarget = true
+		case r == '"':
+			inQuotes = true
+		case r == ',':
+			links = append(links, strings.TrimSpace(current.String()))
+			current.Reset()
+			continue
+		}
+
+		current.WriteRune(r)
+	}
+
+	links = append(links, strings.TrimSpace(current.String()))
+
+	return links
+}
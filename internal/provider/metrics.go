@@ -0,0 +1,192 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Metrics aggregates the requests made by a provider per host, so that a
+// summary can be reported. The JSON report is rewritten whenever the metrics
+// change, as Terraform may kill the provider without waiting for it to shut
+// down.
+type Metrics struct {
+	mu         sync.Mutex
+	hosts      map[string]*hostMetrics
+	reportPath string
+
+	// reportErr is the error of the last write of the JSON report, if any.
+	reportErr error
+}
+
+// hostMetrics are the aggregated requests to a host.
+type hostMetrics struct {
+	Host            string `json:"host"`
+	Requests        int64  `json:"requests"`
+	Failures        int64  `json:"failures"`
+	Retries         int64  `json:"retries"`
+	TotalDurationMS int64  `json:"total_duration_ms"`
+	MaxDurationMS   int64  `json:"max_duration_ms"`
+}
+
+// NewMetrics returns empty metrics.
+func NewMetrics() *Metrics {
+	return &Metrics{
+		hosts: make(map[string]*hostMetrics),
+	}
+}
+
+// host returns the metrics of the host, which must be called with the mutex
+// held.
+func (m *Metrics) host(host string) *hostMetrics {
+	h, ok := m.hosts[host]
+	if !ok {
+		h = &hostMetrics{Host: host}
+		m.hosts[host] = h
+	}
+
+	return h
+}
+
+// recordRequest records a request attempt. A nil Metrics does not record
+// anything.
+func (m *Metrics) recordRequest(host string, duration time.Duration, failed bool) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	h := m.host(host)
+	h.Requests++
+	h.TotalDurationMS += duration.Milliseconds()
+	h.MaxDurationMS = max(h.MaxDurationMS, duration.Milliseconds())
+
+	if failed {
+		h.Failures++
+	}
+
+	m.writeReport()
+}
+
+// recordRetries records the retries of a request.
+func (m *Metrics) recordRetries(host string, retries int64) {
+	if m == nil || retries < 1 {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.host(host).Retries += retries
+
+	m.writeReport()
+}
+
+// setReportPath sets the path of the JSON report, from the provider
+// `metrics_report_path` attribute.
+func (m *Metrics) setReportPath(path string) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.reportPath = path
+
+	if len(m.hosts) > 0 {
+		m.writeReport()
+	}
+}
+
+// summary returns the metrics of the hosts, with the hosts which took the
+// longest in total first. It must be called with the mutex held.
+func (m *Metrics) summary() []hostMetrics {
+	hosts := make([]hostMetrics, 0, len(m.hosts))
+
+	for _, h := range m.hosts {
+		hosts = append(hosts, *h)
+	}
+
+	slices.SortFunc(hosts, func(a, b hostMetrics) int {
+		return cmp.Or(cmp.Compare(b.TotalDurationMS, a.TotalDurationMS), cmp.Compare(a.Host, b.Host))
+	})
+
+	return hosts
+}
+
+// writeReport writes the JSON report if the provider `metrics_report_path`
+// attribute is configured, which must be called with the mutex held. The
+// report is written to a temporary file first, so that a provider which is
+// killed does not leave a partial report behind.
+func (m *Metrics) writeReport() {
+	if m.reportPath == "" {
+		return
+	}
+
+	m.reportErr = nil
+
+	report, err := json.MarshalIndent(struct {
+		Hosts []hostMetrics `json:"hosts"`
+	}{
+		Hosts: m.summary(),
+	}, "", "  ")
+	if err != nil {
+		m.reportErr = fmt.Errorf("error encoding the request metrics report: %w", err)
+		return
+	}
+
+	tmpPath := m.reportPath + ".tmp"
+
+	if err := os.WriteFile(tmpPath, report, 0o600); err != nil {
+		m.reportErr = fmt.Errorf("error writing the request metrics report: %w", err)
+		return
+	}
+
+	if err := os.Rename(tmpPath, m.reportPath); err != nil {
+		m.reportErr = fmt.Errorf("error writing the request metrics report: %w", err)
+	}
+}
+
+// Report logs a summary of the requests per host and returns the error of
+// the last write of the JSON report, if any. Nothing is logged if no requests
+// were made.
+func (m *Metrics) Report() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, h := range m.summary() {
+		log.Printf("[INFO] HTTP request summary: host=%s requests=%d failures=%d retries=%d total_duration_ms=%d max_duration_ms=%d",
+			h.Host, h.Requests, h.Failures, h.Retries, h.TotalDurationMS, h.MaxDurationMS)
+	}
+
+	return m.reportErr
+}
+
+// metricsTransport records the duration and outcome of each request attempt.
+// Attempts which fail or have a 4xx or 5xx status code count as failures.
+type metricsTransport struct {
+	base    http.RoundTripper
+	metrics *Metrics
+}
+
+func (t *metricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+
+	resp, err := t.base.RoundTrip(req)
+
+	t.metrics.recordRequest(req.URL.Host, time.Since(start), err != nil || resp.StatusCode >= http.StatusBadRequest)
+
+	return resp, err
+}
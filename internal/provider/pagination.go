@@ -0,0 +1,268 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ohler55/ojg/jp"
+)
+
+// Pagination modes, configured via `paginate`.
+const (
+	paginateLinkHeader   = "link_header"
+	paginateNextJSONPath = "next_jsonpath"
+	paginatePageParam    = "page_param"
+)
+
+// Defaults for the `paginate` block.
+const (
+	paginateDefaultMaxPages  = 10
+	paginateDefaultPageParam = "page"
+)
+
+type paginateModel struct {
+	Mode          types.String `tfsdk:"mode"`
+	MaxPages      types.Int64  `tfsdk:"max_pages"`
+	NextJSONPath  types.String `tfsdk:"next_jsonpath"`
+	PageParam     types.String `tfsdk:"page_param"`
+	ItemsJSONPath types.String `tfsdk:"items_jsonpath"`
+}
+
+// paginator follows the pages of a paginated response and collects their
+// bodies and items.
+type paginator struct {
+	config paginateModel
+
+	nextJSONPath  jp.Expr
+	itemsJSONPath jp.Expr
+
+	pages []string
+
+	// items are the items of all pages, or nil if the items of a page
+	// could not be determined.
+	items []interface{}
+}
+
+func newPaginator(config paginateModel) (*paginator, error) {
+	p := &paginator{
+		config: config,
+		items:  []interface{}{},
+	}
+
+	if !config.NextJSONPath.IsNull() {
+		x, err := jp.ParseString(config.NextJSONPath.ValueString())
+		if err != nil {
+			return nil, err
+		}
+
+		p.nextJSONPath = x
+	}
+
+	if !config.ItemsJSONPath.IsNull() {
+		x, err := jp.ParseString(config.ItemsJSONPath.ValueString())
+		if err != nil {
+			return nil, err
+		}
+
+		p.itemsJSONPath = x
+	}
+
+	return p, nil
+}
+
+// maxPages returns the maximum number of pages, including the first page.
+func (p *paginator) maxPages() int {
+	if p.config.MaxPages.IsNull() {
+		return paginateDefaultMaxPages
+	}
+
+	return int(p.config.MaxPages.ValueInt64())
+}
+
+// addPage records the body of a page and returns the URL of the next page,
+// or an empty string if there are no more pages.
+func (p *paginator) addPage(response *http.Response, body string) (string, error) {
+	p.pages = append(p.pages, body)
+
+	var document interface{}
+
+	if strings.TrimSpace(body) != "" {
+		var err error
+
+		document, err = parseJSONPathDocument([]byte(body))
+		if err != nil && (p.nextJSONPath != nil || p.itemsJSONPath != nil) {
+			return "", fmt.Errorf("page %d could not be parsed as JSON: %w", len(p.pages), err)
+		}
+	}
+
+	pageItems, ok := p.pageItems(document)
+	if ok && p.items != nil {
+		p.items = append(p.items, pageItems...)
+	} else {
+		p.items = nil
+	}
+
+	if len(p.pages) >= p.maxPages() {
+		return "", nil
+	}
+
+	switch p.config.Mode.ValueString() {
+	case paginateLinkHeader:
+		return parseLinkHeaders(response.Header.Values("Link"), response.Request.URL)["next"], nil
+	case paginateNextJSONPath:
+		results := p.nextJSONPath.Get(document)
+		if len(results) == 0 {
+			return "", nil
+		}
+
+		next, ok := results[0].(string)
+		if !ok || next == "" {
+			return "", nil
+		}
+
+		nextURL, err := url.Parse(next)
+		if err != nil {
+			return "", fmt.Errorf("the next page URL %q of page %d could not be parsed: %w", next, len(p.pages), err)
+		}
+
+		return response.Request.URL.ResolveReference(nextURL).String(), nil
+	case paginatePageParam:
+		// An empty page indicates that there are no more pages.
+		if strings.TrimSpace(body) == "" || (ok && len(pageItems) == 0) {
+			return "", nil
+		}
+
+		return nextPageParamURL(response.Request.URL, p.pageParam())
+	}
+
+	return "", nil
+}
+
+// pageItems returns the items of a page. If `items_jsonpath` is configured,
+// the items are its results, where a single array result is flattened.
+// Otherwise, the page must be a JSON array. The boolean result is false if
+// the items could not be determined.
+func (p *paginator) pageItems(document interface{}) ([]interface{}, bool) {
+	if p.itemsJSONPath == nil {
+		items, ok := document.([]interface{})
+		return items, ok
+	}
+
+	results := p.itemsJSONPath.Get(document)
+	if len(results) == 1 {
+		if items, ok := results[0].([]interface{}); ok {
+			return items, true
+		}
+	}
+
+	return results, true
+}
+
+func (p *paginator) pageParam() string {
+	if p.config.PageParam.IsNull() {
+		return paginateDefaultPageParam
+	}
+
+	return p.config.PageParam.ValueString()
+}
+
+// itemsJSON returns the items of all pages as a JSON array, or null if the
+// items of a page could not be determined.
+func (p *paginator) itemsJSON() (types.String, error) {
+	if p.items == nil {
+		return types.StringNull(), nil
+	}
+
+	b, err := json.Marshal(p.items)
+	if err != nil {
+		return types.StringNull(), err
+	}
+
+	return types.StringValue(string(b)), nil
+}
+
+// nextPageParamURL returns the URL with the page query parameter
+// incremented. A missing parameter is treated as the first page, 1.
+func nextPageParamURL(pageURL *url.URL, param string) (string, error) {
+	query := pageURL.Query()
+
+	page := 1
+
+	if v := query.Get(param); v != "" {
+		var err error
+
+		page, err = strconv.Atoi(v)
+		if err != nil {
+			return "", fmt.Errorf("the %s query parameter %q is not an integer", param, v)
+		}
+	}
+
+	query.Set(param, strconv.Itoa(page+1))
+
+	nextURL := *pageURL
+	nextURL.RawQuery = query.Encode()
+
+	return nextURL.String(), nil
+}
+
+// fetchPage requests a subsequent page using the method, headers and body of
+// the original request.
+func fetchPage(ctx context.Context, client *retryablehttp.Client, original *retryablehttp.Request, requestBody []byte, pageURL string, decodeContentEncoding bool) (*http.Response, string, error) {
+	var rawBody interface{}
+	if requestBody != nil {
+		rawBody = requestBody
+	}
+
+	request, err := retryablehttp.NewRequestWithContext(ctx, original.Method, pageURL, rawBody)
+	if err != nil {
+		return nil, "", err
+	}
+
+	request.Header = original.Header.Clone()
+	request.ContentLength = original.ContentLength
+	request.TransferEncoding = original.TransferEncoding
+
+	// Preserve a Host header override.
+	request.Host = original.Host
+
+	client.ErrorHandler = retryErrorHandler(request.Request)
+
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, "", err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, "", errors.New(response.Status)
+	}
+
+	var reader io.Reader = response.Body
+
+	if decodeContentEncoding {
+		reader, err = contentDecodingReader(response.Header, response.Body)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return response, string(body), nil
+}
@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Preflight modes, configured via `preflight`.
+const (
+	preflightTLSOnly = "tls_only"
+)
+
+// tlsHandshakeAttrTypes are the attribute types of the `tls_handshake` object.
+var tlsHandshakeAttrTypes = map[string]attr.Type{
+	"version":                    types.StringType,
+	"cipher_suite":               types.StringType,
+	"negotiated_protocol":        types.StringType,
+	"peer_certificate_subject":   types.StringType,
+	"peer_certificate_not_after": types.StringType,
+}
+
+// tlsPreflight resolves the host of the given URL, connects to it and
+// performs a TLS handshake using the TLS configuration of the transport,
+// without sending an HTTP request. Proxies are not used.
+func tlsPreflight(ctx context.Context, tr *http.Transport, requestURL string, timeout time.Duration) (tls.ConnectionState, error) {
+	u, err := url.Parse(requestURL)
+	if err != nil {
+		return tls.ConnectionState{}, err
+	}
+
+	if u.Scheme != "https" {
+		return tls.ConnectionState{}, errors.New("TLS preflight requires an https URL")
+	}
+
+	address := u.Host
+	if u.Port() == "" {
+		address = net.JoinHostPort(u.Hostname(), "443")
+	}
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	tlsConfig := tr.TLSClientConfig.Clone()
+	tlsConfig.ServerName = u.Hostname()
+	tlsConfig.NextProtos = []string{"h2", "http/1.1"}
+
+	dialer := &tls.Dialer{Config: tlsConfig}
+
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return tls.ConnectionState{}, err
+	}
+
+	defer conn.Close()
+
+	tlsConn, ok := conn.(*tls.Conn)
+	if !ok {
+		return tls.ConnectionState{}, errors.New("unexpected connection type")
+	}
+
+	return tlsConn.ConnectionState(), nil
+}
+
+// tlsHandshakeValue returns the `tls_handshake` object for the given state.
+func tlsHandshakeValue(state tls.ConnectionState) (types.Object, diag.Diagnostics) {
+	attrs := map[string]attr.Value{
+		"version":                    types.StringValue(tls.VersionName(state.Version)),
+		"cipher_suite":               types.StringValue(tls.CipherSuiteName(state.CipherSuite)),
+		"negotiated_protocol":        types.StringValue(state.NegotiatedProtocol),
+		"peer_certificate_subject":   types.StringNull(),
+		"peer_certificate_not_after": types.StringNull(),
+	}
+
+	if len(state.PeerCertificates) > 0 {
+		attrs["peer_certificate_subject"] = types.StringValue(state.PeerCertificates[0].Subject.String())
+		attrs["peer_certificate_not_after"] = types.StringValue(state.PeerCertificates[0].NotAfter.UTC().Format(time.RFC3339))
+	}
+
+	return types.ObjectValue(tlsHandshakeAttrTypes, attrs)
+}
@@ -0,0 +1,111 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// problemMediaType is the media type of RFC 7807 problem details.
+const problemMediaType = "application/problem+json"
+
+// problemAttrTypes are the attribute types of the `problem` object.
+var problemAttrTypes = map[string]attr.Type{
+	"type":     types.StringType,
+	"title":    types.StringType,
+	"status":   types.Int64Type,
+	"detail":   types.StringType,
+	"instance": types.StringType,
+}
+
+// problemDetails are the standard members of RFC 7807 problem details.
+// Extension members are ignored.
+type problemDetails struct {
+	Type     *string `json:"type"`
+	Title    *string `json:"title"`
+	Status   *int64  `json:"status"`
+	Detail   *string `json:"detail"`
+	Instance *string `json:"instance"`
+}
+
+// responseProblemDetails returns the problem details of a response with the
+// application/problem+json content type. The boolean result is false for
+// other responses or if the body is not a JSON object.
+func responseProblemDetails(header http.Header, body []byte) (problemDetails, bool) {
+	mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if (err != nil && !errors.Is(err, mime.ErrInvalidMediaParameter)) || mediaType != problemMediaType {
+		return problemDetails{}, false
+	}
+
+	return parseProblemDetails(body)
+}
+
+// parseProblemDetails parses RFC 7807 problem details. The boolean result is
+// false if the body is not a JSON object.
+func parseProblemDetails(body []byte) (problemDetails, bool) {
+	var problem problemDetails
+
+	if err := json.Unmarshal(body, &problem); err != nil {
+		return problemDetails{}, false
+	}
+
+	// The type defaults to "about:blank" as described in RFC 7807.
+	if problem.Type == nil {
+		aboutBlank := "about:blank"
+		problem.Type = &aboutBlank
+	}
+
+	return problem, true
+}
+
+// value returns the `problem` object.
+func (p problemDetails) value() (types.Object, diag.Diagnostics) {
+	return types.ObjectValue(problemAttrTypes, map[string]attr.Value{
+		"type":     types.StringPointerValue(p.Type),
+		"title":    types.StringPointerValue(p.Title),
+		"status":   types.Int64PointerValue(p.Status),
+		"detail":   types.StringPointerValue(p.Detail),
+		"instance": types.StringPointerValue(p.Instance),
+	})
+}
+
+// String returns the problem details in a form suitable for diagnostics.
+func (p problemDetails) String() string {
+	var b strings.Builder
+
+	line := func(name, value string) {
+		fmt.Fprintf(&b, "%-9s %s\n", name+":", value)
+	}
+
+	if p.Type != nil {
+		line("Type", *p.Type)
+	}
+
+	if p.Title != nil {
+		line("Title", *p.Title)
+	}
+
+	if p.Status != nil {
+		line("Status", fmt.Sprint(*p.Status))
+	}
+
+	if p.Detail != nil {
+		line("Detail", *p.Detail)
+	}
+
+	if p.Instance != nil {
+		line("Instance", *p.Instance)
+	}
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
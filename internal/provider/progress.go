@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"io"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// progressLogInterval is the minimum interval between progress log entries.
+const progressLogInterval = 5 * time.Second
+
+// progressReader counts the bytes read from a request body, which is read by
+// the transport as it is sent, and periodically logs the upload progress.
+type progressReader struct {
+	ctx    context.Context
+	reader io.Reader
+	size   int64
+
+	read       atomic.Int64
+	lastLogged atomic.Int64
+}
+
+func newProgressReader(ctx context.Context, reader io.Reader, size int64) *progressReader {
+	p := &progressReader{
+		ctx:    ctx,
+		reader: reader,
+		size:   size,
+	}
+
+	p.lastLogged.Store(time.Now().UnixNano())
+
+	return p
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.reader.Read(b)
+	read := p.read.Add(int64(n))
+
+	now := time.Now().UnixNano()
+	last := p.lastLogged.Load()
+
+	if (err == io.EOF || now-last >= int64(progressLogInterval)) && p.lastLogged.CompareAndSwap(last, now) {
+		tflog.Debug(p.ctx, "Request body upload progress", map[string]interface{}{
+			"bytes_sent":  read,
+			"bytes_total": p.size,
+		})
+	}
+
+	return n, err
+}
+
+// Len returns the number of bytes remaining, which allows the content length
+// of the request to be determined.
+func (p *progressReader) Len() int {
+	return int(p.size - p.read.Load())
+}
+
+// BytesRead returns the number of bytes read so far.
+func (p *progressReader) BytesRead() int64 {
+	return p.read.Load()
+}
+
+// byteCounter is a writer which counts the bytes written to it.
+type byteCounter int64
+
+func (c *byteCounter) Write(b []byte) (int, error) {
+	*c += byteCounter(len(b))
+	return len(b), nil
+}
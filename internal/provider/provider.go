@@ -5,28 +5,638 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"regexp"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
 
 func New() provider.Provider {
 	return &httpProvider{}
 }
 
-var _ provider.Provider = (*httpProvider)(nil)
+// NewWithMetrics returns a function which returns a provider recording its
+// requests in the given metrics.
+func NewWithMetrics(metrics *Metrics) func() provider.Provider {
+	return func() provider.Provider {
+		return &httpProvider{
+			metrics: metrics,
+		}
+	}
+}
+
+var (
+	_ provider.Provider                       = (*httpProvider)(nil)
+	_ provider.ProviderWithFunctions          = (*httpProvider)(nil)
+	_ provider.ProviderWithEphemeralResources = (*httpProvider)(nil)
+)
 
-type httpProvider struct{}
+type httpProvider struct {
+	// metrics records the requests of the provider, if set.
+	metrics *Metrics
+}
+
+type providerModel struct {
+	Strict                types.Bool   `tfsdk:"strict"`
+	MaxConcurrentRequests types.Int64  `tfsdk:"max_concurrent_requests"`
+	RetryBudget           types.Int64  `tfsdk:"retry_budget"`
+	DefaultRequestTimeout types.Int64  `tfsdk:"default_request_timeout_ms"`
+	ConnectTimeout        types.Int64  `tfsdk:"connect_timeout_ms"`
+	TLSHandshakeTimeout   types.Int64  `tfsdk:"tls_handshake_timeout_ms"`
+	ResponseHeaderTimeout types.Int64  `tfsdk:"response_header_timeout_ms"`
+	MaxIdleConns          types.Int64  `tfsdk:"max_idle_conns"`
+	MaxIdleConnsPerHost   types.Int64  `tfsdk:"max_idle_conns_per_host"`
+	MaxConnsPerHost       types.Int64  `tfsdk:"max_conns_per_host"`
+	IdleConnTimeout       types.Int64  `tfsdk:"idle_conn_timeout_ms"`
+	DisableKeepAlives     types.Bool   `tfsdk:"disable_keep_alives"`
+	UseProxyFromEnv       types.Bool   `tfsdk:"use_proxy_from_env"`
+	MetricsReportPath     types.String `tfsdk:"metrics_report_path"`
+	TLSCipherSuites       types.List   `tfsdk:"tls_cipher_suites"`
+	TLSRenegotiation      types.String `tfsdk:"tls_renegotiation"`
+	Retry                 types.Object `tfsdk:"retry"`
+	Hosts                 types.List   `tfsdk:"host"`
+	Endpoints             types.List   `tfsdk:"endpoint"`
+	Proxy                 types.Object `tfsdk:"proxy"`
+	RateLimit             types.Object `tfsdk:"rate_limit"`
+	Tracing               types.Object `tfsdk:"tracing"`
+}
+
+type providerRetryModel struct {
+	Attempts types.Int64 `tfsdk:"attempts"`
+	MinDelay types.Int64 `tfsdk:"min_delay_ms"`
+	MaxDelay types.Int64 `tfsdk:"max_delay_ms"`
+}
 
 func (p *httpProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
 	resp.TypeName = "http"
 }
 
-func (p *httpProvider) Schema(context.Context, provider.SchemaRequest, *provider.SchemaResponse) {
+func (p *httpProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"strict": schema.BoolAttribute{
+				Description: "Enables strict mode, which helps migrating away from deprecated attributes before they are " +
+					"removed. Deprecated computed attributes, such as `body` of the `http` data source, are not populated " +
+					"and are always `null`, so that configurations which still rely on them can be identified before " +
+					"the next major release. Defaults to `false`.",
+				Optional: true,
+			},
+
+			"retry_budget": schema.Int64Attribute{
+				Description: "The maximum number of retries across all requests of the provider, so that a widespread " +
+					"outage does not cause a large number of retries. Once the budget has been spent, requests " +
+					"which would be retried fail immediately. By default, the number of retries is only limited by " +
+					"the `retry` block of each data source.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+
+			"max_concurrent_requests": schema.Int64Attribute{
+				Description: "The maximum number of requests in flight at once, across all data sources, regardless " +
+					"of the parallelism of Terraform. A request is in flight until its response body has been read. " +
+					"By default, the number of requests is not limited.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"default_request_timeout_ms": schema.Int64Attribute{
+				Description: "The default `request_timeout_ms` of data sources, so that a data source without a timeout " +
+					"cannot hang a plan indefinitely. This takes precedence over the `TF_HTTP_REQUEST_TIMEOUT_MS` " +
+					"environment variable.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"connect_timeout_ms": schema.Int64Attribute{
+				Description: "The default `connect_timeout_ms` of data sources.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"tls_handshake_timeout_ms": schema.Int64Attribute{
+				Description: "The default `tls_handshake_timeout_ms` of data sources.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"response_header_timeout_ms": schema.Int64Attribute{
+				Description: "The default `response_header_timeout_ms` of data sources.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"tls_cipher_suites": schema.ListAttribute{
+				Description: "The default `tls_cipher_suites` of `http` data sources, which also applies to the " +
+					"other data sources.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.OneOf(cipherSuiteNames()...)),
+				},
+			},
+
+			"tls_renegotiation": schema.StringAttribute{
+				Description: "The default `tls_renegotiation` of `http` data sources, which also applies to the " +
+					"other data sources.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("never", "once", "freely"),
+				},
+			},
+
+			"max_idle_conns": schema.Int64Attribute{
+				Description: "The maximum number of idle (keep-alive) connections across all hosts. Zero means no " +
+					"limit. Defaults to `100`.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+
+			"max_idle_conns_per_host": schema.Int64Attribute{
+				Description: "The maximum number of idle (keep-alive) connections to keep per host. Defaults to `2`.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"max_conns_per_host": schema.Int64Attribute{
+				Description: "The maximum number of connections per host, including connections in the dialing, " +
+					"active, and idle states. Requests wait for a connection once the limit is reached. Zero means " +
+					"no limit. Defaults to `0`.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+
+			"idle_conn_timeout_ms": schema.Int64Attribute{
+				Description: "The time an idle (keep-alive) connection remains open before closing itself, in " +
+					"milliseconds. Zero means no limit. Defaults to `90000`.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+
+			"disable_keep_alives": schema.BoolAttribute{
+				Description: "Disables HTTP keep-alives, so that each connection is only used for a single request. " +
+					"Defaults to `false`.",
+				Optional: true,
+			},
+
+			"use_proxy_from_env": schema.BoolAttribute{
+				Description: "Whether the proxies configured by the `HTTP_PROXY`, `HTTPS_PROXY`, `NO_PROXY` and " +
+					"`TF_HTTP_PROXY` environment variables are used. Set to `false` to connect directly, unless the " +
+					"provider `proxy` block is configured, even when a proxy is configured for the environment. " +
+					"Defaults to `true`.",
+				Optional: true,
+			},
+
+			"metrics_report_path": schema.StringAttribute{
+				Description: "The path of a JSON file to which the number of requests, failures and retries and the " +
+					"durations of requests are written per host, so that slow endpoints can be identified. The file is " +
+					"updated after each request, so that it is complete even if the provider is stopped without " +
+					"shutting down. A summary is also logged at the `INFO` level when the provider shuts down, if " +
+					"Terraform still reads its logs by then.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"endpoint": schema.ListNestedBlock{
+				Description: "A named base URL, which `http` data sources refer to with `endpoint`, so that " +
+					"environment-specific hosts are configured once.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "The name of the endpoint.",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"base_url": schema.StringAttribute{
+							Description: "The base URL, such as `https://artifactory.example.com/api`. The `url` of " +
+								"data sources is appended to it.",
+							Required: true,
+							Validators: []validator.String{
+								stringvalidator.RegexMatches(regexp.MustCompile(`^https?://`), "must be an http or https URL"),
+							},
+						},
+					},
+				},
+			},
+
+			"host": schema.ListNestedBlock{
+				Description: "Configuration which applies to requests whose URL host matches `name`, such as default " +
+					"request headers, credentials, TLS settings and timeouts. Attributes set on data sources take " +
+					"precedence. If several blocks match, the first one applies.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "The host name, without port, such as `api.example.com`. A name starting with " +
+								"`*.`, such as `*.example.com`, matches any subdomain.",
+							Required: true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"request_headers": schema.MapAttribute{
+							Description: "A map of default request header field names and values.",
+							ElementType: types.StringType,
+							Optional:    true,
+						},
+						"username": schema.StringAttribute{
+							Description: "The username for HTTP basic authentication.",
+							Optional:    true,
+							Validators: []validator.String{
+								stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("password")),
+							},
+						},
+						"password": schema.StringAttribute{
+							Description: "The password for HTTP basic authentication.",
+							Optional:    true,
+							Sensitive:   true,
+							Validators: []validator.String{
+								stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("username")),
+							},
+						},
+						"bearer_token": schema.StringAttribute{
+							Description: "The token sent in an `Authorization: Bearer` request header.",
+							Optional:    true,
+							Sensitive:   true,
+							Validators: []validator.String{
+								stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("username")),
+							},
+						},
+						"ca_cert_pem": schema.StringAttribute{
+							Description: "Certificate data of the Certificate Authority (CA) " +
+								"in [PEM (RFC 1421)](https://datatracker.ietf.org/doc/html/rfc1421) format.",
+							Optional: true,
+							Validators: []validator.String{
+								stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("insecure")),
+							},
+						},
+						"insecure": schema.BoolAttribute{
+							Description: "Disables verification of the server's certificate chain and hostname.",
+							Optional:    true,
+						},
+						"request_timeout_ms": schema.Int64Attribute{
+							Description: "The default `request_timeout_ms` of data sources.",
+							Optional:    true,
+							Validators: []validator.Int64{
+								int64validator.AtLeast(1),
+							},
+						},
+						"connect_timeout_ms": schema.Int64Attribute{
+							Description: "The default `connect_timeout_ms` of data sources.",
+							Optional:    true,
+							Validators: []validator.Int64{
+								int64validator.AtLeast(1),
+							},
+						},
+						"tls_handshake_timeout_ms": schema.Int64Attribute{
+							Description: "The default `tls_handshake_timeout_ms` of data sources.",
+							Optional:    true,
+							Validators: []validator.Int64{
+								int64validator.AtLeast(1),
+							},
+						},
+						"response_header_timeout_ms": schema.Int64Attribute{
+							Description: "The default `response_header_timeout_ms` of data sources.",
+							Optional:    true,
+							Validators: []validator.Int64{
+								int64validator.AtLeast(1),
+							},
+						},
+					},
+					Blocks: map[string]schema.Block{
+						"rate_limit": rateLimitBlock("Limits the rate of requests to the matching hosts, instead of the " +
+							"provider `rate_limit`. The limit is shared by all data sources."),
+					},
+				},
+			},
+
+			"proxy": schema.SingleNestedBlock{
+				Description: "The proxy used for requests, instead of the proxies configured by the `HTTP_PROXY` and " +
+					"`HTTPS_PROXY` environment variables. This takes precedence over the `TF_HTTP_PROXY` environment " +
+					"variable.",
+				Attributes: map[string]schema.Attribute{
+					"url": schema.StringAttribute{
+						Description: "The URL of the proxy used for both `http` and `https` requests, such as " +
+							"`http://proxy.example.com:3128`.",
+						Optional: true,
+					},
+					"http_url": schema.StringAttribute{
+						Description: "The URL of the proxy used for `http` requests. Defaults to `url`.",
+						Optional:    true,
+					},
+					"https_url": schema.StringAttribute{
+						Description: "The URL of the proxy used for `https` requests. Defaults to `url`.",
+						Optional:    true,
+					},
+					"username": schema.StringAttribute{
+						Description: "The username for authenticating with the proxy.",
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("password")),
+						},
+					},
+					"password": schema.StringAttribute{
+						Description: "The password for authenticating with the proxy.",
+						Optional:    true,
+						Sensitive:   true,
+						Validators: []validator.String{
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("username")),
+						},
+					},
+					"no_proxy": schema.ListAttribute{
+						Description: "A list of hosts, such as `internal.example.com`, domains, such as `.example.com`, " +
+							"and CIDR ranges, such as `10.0.0.0/8`, which are requested directly instead of through " +
+							"a proxy, in addition to those of the `NO_PROXY` environment variable. Entries may " +
+							"include a port, such as `internal.example.com:8080`. This applies to proxies configured " +
+							"by environment variables as well.",
+						ElementType: types.StringType,
+						Optional:    true,
+						Validators: []validator.List{
+							listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+						},
+					},
+				},
+			},
+
+			"rate_limit": rateLimitBlock("Limits the rate of requests, using a token bucket shared by all data " +
+				"sources, so that many concurrent reads do not exceed the rate limit of an API. Retries are limited as well."),
+
+			"retry": schema.SingleNestedBlock{
+				Description: "The default retry request configuration of `http` data sources which do not configure " +
+					"their own `retry` block, so that a common retry policy does not have to be repeated.",
+				Attributes: map[string]schema.Attribute{
+					"attempts": schema.Int64Attribute{
+						Description: "The number of times the request is to be retried.",
+						Optional:    true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(0),
+						},
+					},
+					"min_delay_ms": schema.Int64Attribute{
+						Description: "The minimum delay between retry requests in milliseconds.",
+						Optional:    true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(0),
+						},
+					},
+					"max_delay_ms": schema.Int64Attribute{
+						Description: "The maximum delay between retry requests in milliseconds.",
+						Optional:    true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(0),
+							int64validator.AtLeastSumOf(path.MatchRelative().AtParent().AtName("min_delay_ms")),
+						},
+					},
+				},
+			},
+
+			"tracing": schema.SingleNestedBlock{
+				Description: "Exports a span for each read of an `http` data source and for each request attempt to an " +
+					"OpenTelemetry collector, using OTLP over HTTP. The W3C `traceparent` header is sent with requests, " +
+					"so that the spans of the server are part of the same trace.",
+				Validators: []validator.Object{
+					objectvalidator.AlsoRequires(path.MatchRelative().AtName("endpoint")),
+				},
+				Attributes: map[string]schema.Attribute{
+					"endpoint": schema.StringAttribute{
+						Description: "The URL to which spans are exported, such as `http://localhost:4318/v1/traces`.",
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(regexp.MustCompile(`^https?://`), "must be an http or https URL"),
+						},
+					},
+					"headers": schema.MapAttribute{
+						Description: "The headers sent with exports, such as an API key of the collector.",
+						ElementType: types.StringType,
+						Optional:    true,
+						Sensitive:   true,
+					},
+					"service_name": schema.StringAttribute{
+						Description: "The `service.name` resource attribute of the spans. Defaults to `" + tracingDefaultServiceName + "`.",
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+						},
+					},
+				},
+			},
+		},
+	}
 }
 
-func (p *httpProvider) Configure(context.Context, provider.ConfigureRequest, *provider.ConfigureResponse) {
+func (p *httpProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	var config providerModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data, diags := newProviderData()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.strict = config.Strict.ValueBool()
+	data.metrics = p.metrics
+
+	p.metrics.setReportPath(config.MetricsReportPath.ValueString())
+
+	if !config.RetryBudget.IsNull() {
+		data.retryBudget = newRetryBudget(config.RetryBudget.ValueInt64())
+	}
+
+	if !config.MaxConcurrentRequests.IsNull() {
+		data.requestSlots = make(chan struct{}, config.MaxConcurrentRequests.ValueInt64())
+	}
+
+	if !config.DefaultRequestTimeout.IsNull() {
+		data.requestTimeout = millisecondsDuration(config.DefaultRequestTimeout)
+	}
+
+	data.connectTimeout = millisecondsDuration(config.ConnectTimeout)
+	data.tlsHandshakeTimeout = millisecondsDuration(config.TLSHandshakeTimeout)
+	data.responseHeaderTimeout = millisecondsDuration(config.ResponseHeaderTimeout)
+
+	data.cipherSuites, diags = cipherSuiteIDs(ctx, config.TLSCipherSuites)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data = data.withRenegotiation(config.TLSRenegotiation)
+
+	if !config.MaxIdleConns.IsNull() || !config.MaxIdleConnsPerHost.IsNull() || !config.MaxConnsPerHost.IsNull() ||
+		!config.IdleConnTimeout.IsNull() || !config.DisableKeepAlives.IsNull() {
+		data.connectionPool = &connectionPool{
+			maxIdleConns:        optionalInt(config.MaxIdleConns),
+			maxIdleConnsPerHost: optionalInt(config.MaxIdleConnsPerHost),
+			maxConnsPerHost:     optionalInt(config.MaxConnsPerHost),
+			disableKeepAlives:   config.DisableKeepAlives.ValueBool(),
+		}
+
+		if !config.IdleConnTimeout.IsNull() {
+			idleConnTimeout := millisecondsDuration(config.IdleConnTimeout)
+			data.connectionPool.idleConnTimeout = &idleConnTimeout
+		}
+	}
+
+	if !config.Retry.IsNull() && !config.Retry.IsUnknown() {
+		var retry providerRetryModel
+
+		resp.Diagnostics.Append(config.Retry.As(ctx, &retry, basetypes.ObjectAsOptions{})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		data.retry = &retryModel{
+			Attempts: retry.Attempts,
+			MinDelay: retry.MinDelay,
+			MaxDelay: retry.MaxDelay,
+		}
+	}
+
+	if !config.UseProxyFromEnv.IsNull() && !config.UseProxyFromEnv.ValueBool() {
+		data.ignoreProxyEnv = true
+		data.httpProxy = ""
+		data.httpsProxy = ""
+	}
+
+	if !config.Proxy.IsNull() && !config.Proxy.IsUnknown() {
+		var proxy providerProxyModel
+
+		resp.Diagnostics.Append(config.Proxy.As(ctx, &proxy, basetypes.ObjectAsOptions{})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		httpProxy, httpsProxy, diags := proxy.proxyURLs(path.Root("proxy"))
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if httpProxy != "" {
+			data.httpProxy = httpProxy
+		}
+
+		if httpsProxy != "" {
+			data.httpsProxy = httpsProxy
+		}
+
+		data.noProxy, diags = proxy.noProxy(ctx, path.Root("proxy").AtName("no_proxy"))
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	data.rateLimiter, diags = newRateLimiter(ctx, config.RateLimit)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Tracing.IsNull() && !config.Tracing.IsUnknown() {
+		var tracing providerTracingModel
+
+		resp.Diagnostics.Append(config.Tracing.As(ctx, &tracing, basetypes.ObjectAsOptions{})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		data.tracerProvider, diags = newTracerProvider(ctx, tracing)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if !config.Endpoints.IsNull() && !config.Endpoints.IsUnknown() {
+		var endpoints []providerEndpointModel
+
+		resp.Diagnostics.Append(config.Endpoints.ElementsAs(ctx, &endpoints, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		data.endpoints = make(map[string]string, len(endpoints))
+
+		for i, endpoint := range endpoints {
+			name := endpoint.Name.ValueString()
+
+			if _, ok := data.endpoints[name]; ok {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("endpoint").AtListIndex(i).AtName("name"),
+					"Duplicate Endpoint Name",
+					fmt.Sprintf("The endpoint %q is configured more than once.", name),
+				)
+				return
+			}
+
+			data.endpoints[name] = endpoint.BaseURL.ValueString()
+		}
+	}
+
+	if !config.Hosts.IsNull() && !config.Hosts.IsUnknown() {
+		var hosts []providerHostModel
+
+		resp.Diagnostics.Append(config.Hosts.ElementsAs(ctx, &hosts, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		for _, hostModel := range hosts {
+			host, diags := newHostConfig(ctx, hostModel)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			data.hosts = append(data.hosts, host)
+		}
+	}
+
+	resp.DataSourceData = data
+	resp.EphemeralResourceData = data
 }
 
 func (p *httpProvider) Resources(context.Context) []func() resource.Resource {
@@ -36,5 +646,28 @@ func (p *httpProvider) Resources(context.Context) []func() resource.Resource {
 func (p *httpProvider) DataSources(context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewHttpDataSource,
+		NewFileDataSource,
+		NewMultiDataSource,
+		NewDNSDataSource,
+		NewLatestVersionDataSource,
+		NewRobotsTxtDataSource,
+		NewWaitDataSource,
+	}
+}
+
+func (p *httpProvider) EphemeralResources(context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewOAuth2TokenEphemeralResource,
+		NewSessionEphemeralResource,
+	}
+}
+
+func (p *httpProvider) Functions(context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewParseHeaderValuesFunction,
+		NewURLEncodeFunction,
+		NewURLEncodeQueryFunction,
+		NewURLDecodeFunction,
+		NewURLDecodeQueryFunction,
 	}
 }
@@ -0,0 +1,363 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"golang.org/x/time/rate"
+)
+
+// Environment variables providing defaults for data source attributes.
+const (
+	envInsecure       = "TF_HTTP_INSECURE"
+	envCACertFile     = "TF_HTTP_CA_CERT_FILE"
+	envCABundle       = "TF_HTTP_CA_BUNDLE"
+	envRequestTimeout = "TF_HTTP_REQUEST_TIMEOUT_MS"
+	envTimeout        = "TF_HTTP_TIMEOUT_MS"
+	envProxy          = "TF_HTTP_PROXY"
+	envDefaultHeaders = "TF_HTTP_DEFAULT_HEADERS"
+)
+
+// lookupEnv returns the name and value of the first of the environment
+// variables which is set, so that aliases are supported.
+func lookupEnv(names ...string) (string, string) {
+	for _, name := range names {
+		if v := os.Getenv(name); v != "" {
+			return name, v
+		}
+	}
+
+	return "", ""
+}
+
+// providerData is the provider-level configuration, passed to data sources
+// when the provider is configured.
+type providerData struct {
+	// insecure is the default for `insecure`, if set.
+	insecure *bool
+
+	// caCertPEM is the default for `ca_cert_pem`, if set.
+	caCertPEM string
+
+	// requestTimeout is the default for `request_timeout_ms`, if set.
+	requestTimeout time.Duration
+
+	// httpProxy and httpsProxy are the proxies used for http and https
+	// requests instead of the proxies configured by the HTTP_PROXY and
+	// HTTPS_PROXY environment variables, if set.
+	httpProxy  string
+	httpsProxy string
+
+	// noProxy are the hosts, domains and CIDR ranges which are requested
+	// directly, in addition to those of the NO_PROXY environment variable.
+	noProxy []string
+
+	// ignoreProxyEnv is true if the proxy environment variables are ignored,
+	// from `use_proxy_from_env = false`.
+	ignoreProxyEnv bool
+
+	// strict is true if deprecated attributes are not populated.
+	strict bool
+
+	// connectTimeout, tlsHandshakeTimeout and responseHeaderTimeout are the
+	// defaults for `connect_timeout_ms`, `tls_handshake_timeout_ms` and
+	// `response_header_timeout_ms`, if set.
+	connectTimeout        time.Duration
+	tlsHandshakeTimeout   time.Duration
+	responseHeaderTimeout time.Duration
+
+	// retry is the default `retry` block of the `http` data source, if set.
+	retry *retryModel
+
+	// cipherSuites is the default for `tls_cipher_suites`, if set.
+	cipherSuites []uint16
+
+	// renegotiation is the default for `tls_renegotiation`.
+	renegotiation tls.RenegotiationSupport
+
+	// pinnedPublicKeys are the `pinned_public_keys` of a data source, if set.
+	pinnedPublicKeys []string
+
+	// crls are the certificate revocation lists of a data source, if set.
+	crls []*x509.RevocationList
+
+	// requireOCSPStaple and ocspCheck are the `require_ocsp_staple` and
+	// `ocsp_check` settings of a data source.
+	requireOCSPStaple bool
+	ocspCheck         bool
+
+	// retryBudget limits the total number of retries of all data sources, if
+	// set.
+	retryBudget *retryBudget
+
+	// rateLimiter limits the rate of all requests, if set.
+	rateLimiter *rate.Limiter
+
+	// requestSlots is a semaphore limiting the number of concurrent requests,
+	// if set.
+	requestSlots chan struct{}
+
+	// connectionPool is the connection pool configuration of transports, if
+	// set.
+	connectionPool *connectionPool
+
+	// transports are the transports shared by data sources.
+	transports *transportCache
+
+	// metrics records the requests of all data sources, if set.
+	metrics *Metrics
+
+	// tracerProvider exports the spans of requests, if the provider `tracing`
+	// block is configured.
+	tracerProvider *sdktrace.TracerProvider
+
+	// endpoints are the base URLs of the provider `endpoint` blocks by name.
+	endpoints map[string]string
+
+	// hosts are the provider `host` blocks, in order.
+	hosts []hostConfig
+
+	// requestHeaders are the default request headers, from the
+	// TF_HTTP_DEFAULT_HEADERS environment variable and the matching `host`
+	// block, as returned by forURL.
+	requestHeaders map[string]string
+}
+
+// newProviderData returns the provider-level configuration, reading defaults
+// from TF_HTTP_* environment variables.
+func newProviderData() (*providerData, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	data := &providerData{
+		transports: newTransportCache(),
+	}
+
+	if v := os.Getenv(envInsecure); v != "" {
+		insecure, err := strconv.ParseBool(v)
+		if err != nil {
+			diags.AddError(
+				"Invalid environment variable value",
+				fmt.Sprintf("The %s environment variable must be a boolean, got: %q", envInsecure, v),
+			)
+		} else {
+			data.insecure = &insecure
+		}
+	}
+
+	if name, v := lookupEnv(envCACertFile, envCABundle); v != "" {
+		caCertPEM, err := os.ReadFile(v)
+		if err != nil {
+			diags.AddError(
+				"Invalid environment variable value",
+				fmt.Sprintf("The file referenced by the %s environment variable could not be read: %s", name, err),
+			)
+		} else {
+			data.caCertPEM = string(caCertPEM)
+		}
+	}
+
+	if name, v := lookupEnv(envRequestTimeout, envTimeout); v != "" {
+		timeout, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || timeout < 1 {
+			diags.AddError(
+				"Invalid environment variable value",
+				fmt.Sprintf("The %s environment variable must be a positive integer, got: %q", name, v),
+			)
+		} else {
+			data.requestTimeout = time.Duration(timeout) * time.Millisecond
+		}
+	}
+
+	if v := os.Getenv(envProxy); v != "" {
+		if _, err := url.Parse(v); err != nil {
+			diags.AddError(
+				"Invalid environment variable value",
+				fmt.Sprintf("The %s environment variable must be a URL: %s", envProxy, err),
+			)
+		} else {
+			data.httpProxy = v
+			data.httpsProxy = v
+		}
+	}
+
+	if v := os.Getenv(envDefaultHeaders); v != "" {
+		if err := json.Unmarshal([]byte(v), &data.requestHeaders); err != nil {
+			diags.AddError(
+				"Invalid environment variable value",
+				fmt.Sprintf("The %s environment variable must be a JSON object of header names and string values: %s", envDefaultHeaders, err),
+			)
+		}
+	}
+
+	return data, diags
+}
+
+// requestTimeout returns the configured `request_timeout_ms` as a duration,
+// falling back to the provider default. A zero duration means no timeout.
+func requestTimeout(p *providerData, timeout types.Int64) time.Duration {
+	if timeout.ValueInt64() > 0 {
+		return time.Duration(timeout.ValueInt64()) * time.Millisecond
+	}
+
+	if p != nil {
+		return p.requestTimeout
+	}
+
+	return 0
+}
+
+// withTimeouts returns the provider configuration with the given timeouts of
+// the individual phases of a request taking precedence. A zero duration
+// leaves the corresponding timeout unchanged.
+func (p *providerData) withTimeouts(connect, tlsHandshake, responseHeader time.Duration) *providerData {
+	if p == nil {
+		p = &providerData{}
+	}
+
+	data := *p
+
+	if connect > 0 {
+		data.connectTimeout = connect
+	}
+
+	if tlsHandshake > 0 {
+		data.tlsHandshakeTimeout = tlsHandshake
+	}
+
+	if responseHeader > 0 {
+		data.responseHeaderTimeout = responseHeader
+	}
+
+	return &data
+}
+
+// withCipherSuites returns the provider configuration with the given cipher
+// suites taking precedence, unless they are nil.
+func (p *providerData) withCipherSuites(cipherSuites []uint16) *providerData {
+	if p == nil {
+		p = &providerData{}
+	}
+
+	if cipherSuites == nil {
+		return p
+	}
+
+	data := *p
+	data.cipherSuites = cipherSuites
+
+	return &data
+}
+
+// withRenegotiation returns the provider configuration with the given
+// `tls_renegotiation` setting, unless it is null.
+func (p *providerData) withRenegotiation(renegotiation types.String) *providerData {
+	if p == nil {
+		p = &providerData{}
+	}
+
+	if renegotiation.IsNull() {
+		return p
+	}
+
+	data := *p
+	data.renegotiation = tlsRenegotiation[renegotiation.ValueString()]
+
+	return &data
+}
+
+// withPinnedPublicKeys returns the provider configuration with the given
+// pinned public keys.
+func (p *providerData) withPinnedPublicKeys(pins []string) *providerData {
+	if p == nil {
+		p = &providerData{}
+	}
+
+	if len(pins) == 0 {
+		return p
+	}
+
+	data := *p
+	data.pinnedPublicKeys = pins
+
+	return &data
+}
+
+// withCRLs returns the provider configuration with the given certificate
+// revocation lists.
+func (p *providerData) withCRLs(crls []*x509.RevocationList) *providerData {
+	if p == nil {
+		p = &providerData{}
+	}
+
+	if len(crls) == 0 {
+		return p
+	}
+
+	data := *p
+	data.crls = crls
+
+	return &data
+}
+
+// withOCSP returns the provider configuration with the given OCSP settings.
+func (p *providerData) withOCSP(requireStaple, check bool) *providerData {
+	if p == nil {
+		p = &providerData{}
+	}
+
+	data := *p
+	data.requireOCSPStaple = requireStaple
+	data.ocspCheck = check
+
+	return &data
+}
+
+// millisecondsDuration returns a number of milliseconds as a duration. A null
+// value results in a zero duration.
+func millisecondsDuration(ms types.Int64) time.Duration {
+	return time.Duration(ms.ValueInt64()) * time.Millisecond
+}
+
+// optionalInt returns the value as an int, or nil if it is null.
+func optionalInt(value types.Int64) *int {
+	if value.IsNull() {
+		return nil
+	}
+
+	v := int(value.ValueInt64())
+
+	return &v
+}
+
+// configureProviderData returns the providerData passed to a data source
+// Configure method, or nil if the provider has not been configured yet.
+func configureProviderData(providerDataValue any) (*providerData, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if providerDataValue == nil {
+		return nil, diags
+	}
+
+	data, ok := providerDataValue.(*providerData)
+	if !ok {
+		diags.AddError(
+			"Unexpected Provider Data Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", providerDataValue),
+		)
+		return nil, diags
+	}
+
+	return data, diags
+}
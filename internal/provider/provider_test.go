@@ -6,6 +6,8 @@ package provider
 import (
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
 )
 
 //nolint:unparam
@@ -14,3 +16,12 @@ func protoV5ProviderFactories() map[string]func() (tfprotov5.ProviderServer, err
 		"http": providerserver.NewProtocol5WithError(New()),
 	}
 }
+
+// protoV6EchoProviderFactories returns the echo provider, which stores the
+// values of ephemeral resources in the state of an `echo` resource, so that
+// they can be checked.
+func protoV6EchoProviderFactories() map[string]func() (tfprotov6.ProviderServer, error) {
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		"echo": echoprovider.NewProviderServer(),
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type providerProxyModel struct {
+	URL      types.String `tfsdk:"url"`
+	HTTPURL  types.String `tfsdk:"http_url"`
+	HTTPSURL types.String `tfsdk:"https_url"`
+	Username types.String `tfsdk:"username"`
+	Password types.String `tfsdk:"password"`
+	NoProxy  types.List   `tfsdk:"no_proxy"`
+}
+
+// proxyURLs returns the proxies for http and https requests configured by the
+// provider `proxy` block, including the credentials. An empty string means
+// that the block does not configure a proxy for the scheme.
+func (m providerProxyModel) proxyURLs(blockPath path.Path) (string, string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	httpProxy, httpDiags := m.proxyURL(blockPath.AtName("http_url"), m.HTTPURL)
+	diags.Append(httpDiags...)
+
+	httpsProxy, httpsDiags := m.proxyURL(blockPath.AtName("https_url"), m.HTTPSURL)
+	diags.Append(httpsDiags...)
+
+	return httpProxy, httpsProxy, diags
+}
+
+// proxyURL returns the scheme-specific proxy URL, falling back to `url`, with
+// the configured credentials.
+func (m providerProxyModel) proxyURL(attributePath path.Path, schemeURL types.String) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	value := schemeURL
+	if value.IsNull() {
+		value = m.URL
+		attributePath = attributePath.ParentPath().AtName("url")
+	}
+
+	if value.IsNull() {
+		return "", diags
+	}
+
+	proxyURL, err := url.Parse(value.ValueString())
+	if err != nil || proxyURL.Host == "" {
+		diags.AddAttributeError(
+			attributePath,
+			"Invalid Proxy URL",
+			fmt.Sprintf("The proxy URL %q must be an absolute URL, such as http://proxy.example.com:3128.", value.ValueString()),
+		)
+		return "", diags
+	}
+
+	if !m.Username.IsNull() {
+		proxyURL.User = url.UserPassword(m.Username.ValueString(), m.Password.ValueString())
+	}
+
+	return proxyURL.String(), diags
+}
+
+// noProxy returns the `no_proxy` entries, checking that CIDR ranges are valid.
+func (m providerProxyModel) noProxy(ctx context.Context, attributePath path.Path) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if m.NoProxy.IsNull() || m.NoProxy.IsUnknown() {
+		return nil, diags
+	}
+
+	var noProxy []string
+
+	diags.Append(m.NoProxy.ElementsAs(ctx, &noProxy, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	for i, entry := range noProxy {
+		if !strings.Contains(entry, "/") {
+			continue
+		}
+
+		if _, _, err := net.ParseCIDR(entry); err != nil {
+			diags.AddAttributeError(
+				attributePath.AtListIndex(i),
+				"Invalid CIDR Range",
+				fmt.Sprintf("The no_proxy entry %q could not be parsed as a CIDR range: %s", entry, err),
+			)
+		}
+	}
+
+	return noProxy, diags
+}
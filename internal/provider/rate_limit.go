@@ -0,0 +1,91 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"golang.org/x/time/rate"
+)
+
+type rateLimitModel struct {
+	RequestsPerSecond types.Float64 `tfsdk:"requests_per_second"`
+	Burst             types.Int64   `tfsdk:"burst"`
+}
+
+// rateLimitBlock returns the schema of the `rate_limit` block of the provider
+// and of its `host` blocks.
+func rateLimitBlock(description string) schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Description: description,
+		Validators: []validator.Object{
+			objectvalidator.AlsoRequires(path.MatchRelative().AtName("requests_per_second")),
+		},
+		Attributes: map[string]schema.Attribute{
+			"requests_per_second": schema.Float64Attribute{
+				Description: "The sustained number of requests per second, such as `10` or `0.5`.",
+				Optional:    true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0.001),
+				},
+			},
+			"burst": schema.Int64Attribute{
+				Description: "The number of requests which may be made at once before the rate applies. Defaults to `1`.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+// newRateLimiter returns the token bucket configured by a `rate_limit` block,
+// or nil if the block is not configured.
+func newRateLimiter(ctx context.Context, block types.Object) (*rate.Limiter, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if block.IsNull() || block.IsUnknown() {
+		return nil, diags
+	}
+
+	var model rateLimitModel
+
+	diags.Append(block.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() || model.RequestsPerSecond.IsNull() {
+		return nil, diags
+	}
+
+	burst := 1
+	if !model.Burst.IsNull() {
+		burst = int(model.Burst.ValueInt64())
+	}
+
+	return rate.NewLimiter(rate.Limit(model.RequestsPerSecond.ValueFloat64()), burst), diags
+}
+
+// rateLimitedTransport waits for the limiter before each request, including
+// retries, so that the limit is shared by all data sources.
+type rateLimitedTransport struct {
+	base    http.RoundTripper
+	limiter *rate.Limiter
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,146 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Formats of request failure diagnostics, configured via `error_detail`.
+const (
+	errorDetailFull    = "full"
+	errorDetailCompact = "compact"
+)
+
+// Machine-readable classifications of request failures, included in the
+// diagnostic detail so automation can branch on the type of failure.
+const (
+	errorCodeDNS               = "dns_error"
+	errorCodeTLSVerifyFailed   = "tls_verify_failed"
+	errorCodeTimeout           = "timeout"
+	errorCodeConnectionRefused = "connection_refused"
+	errorCodeHTTPStatus        = "http_status"
+	errorCodeUnknown           = "unknown"
+)
+
+// retryError is returned by the retry client once it gives up on a request.
+// It retains the status code of the final response, if any, which the
+// default retryablehttp error handler discards.
+type retryError struct {
+	method     string
+	url        string
+	attempts   int
+	statusCode int
+	err        error
+}
+
+func (e *retryError) Error() string {
+	if e.err == nil {
+		return fmt.Sprintf("%s %s giving up after %d attempt(s)", e.method, e.url, e.attempts)
+	}
+
+	return fmt.Sprintf("%s %s giving up after %d attempt(s): %s", e.method, e.url, e.attempts, e.err)
+}
+
+func (e *retryError) Unwrap() error {
+	return e.err
+}
+
+// retryErrorHandler returns a retryablehttp.ErrorHandler which closes the
+// final response body and returns a *retryError.
+func retryErrorHandler(req *http.Request) func(*http.Response, error, int) (*http.Response, error) {
+	return func(resp *http.Response, err error, numTries int) (*http.Response, error) {
+		retryErr := &retryError{
+			method:   req.Method,
+			url:      req.URL.Redacted(),
+			attempts: numTries,
+			err:      err,
+		}
+
+		if resp != nil {
+			retryErr.statusCode = resp.StatusCode
+			resp.Body.Close()
+		}
+
+		return nil, retryErr
+	}
+}
+
+// requestErrorCode classifies an error returned when making a request.
+func requestErrorCode(err error) string {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return errorCodeDNS
+	}
+
+	var certVerificationErr *tls.CertificateVerificationError
+	var unknownAuthorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var certInvalidErr x509.CertificateInvalidError
+	if errors.As(err, &certVerificationErr) || errors.As(err, &unknownAuthorityErr) ||
+		errors.As(err, &hostnameErr) || errors.As(err, &certInvalidErr) {
+		return errorCodeTLSVerifyFailed
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return errorCodeTimeout
+	}
+
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return errorCodeConnectionRefused
+	}
+
+	var retryErr *retryError
+	if errors.As(err, &retryErr) && retryErr.err == nil && retryErr.statusCode != 0 {
+		return errorCodeHTTPStatus
+	}
+
+	return errorCodeUnknown
+}
+
+// compactRequestError returns a single line description of a request failure,
+// containing the request, the number of attempts, the underlying cause and
+// the error code.
+func compactRequestError(err error, errorCode string, timeout time.Duration) string {
+	var b strings.Builder
+
+	cause := err
+
+	var retryErr *retryError
+	if errors.As(err, &retryErr) {
+		fmt.Fprintf(&b, "%s %s failed after %d attempt(s): ", retryErr.method, retryErr.url, retryErr.attempts)
+
+		cause = retryErr.err
+		if cause == nil {
+			cause = fmt.Errorf("unexpected status code %d", retryErr.statusCode)
+		}
+	}
+
+	// The URL is already part of the message, so only include the cause.
+	var urlErr *url.Error
+	if errors.As(cause, &urlErr) {
+		cause = urlErr.Err
+	}
+
+	b.WriteString(strings.Join(strings.Fields(cause.Error()), " "))
+
+	if errorCode == errorCodeTimeout && timeout > 0 {
+		fmt.Fprintf(&b, " (request_timeout_ms: %d)", timeout.Milliseconds())
+	}
+
+	fmt.Fprintf(&b, " [error_code: %s]", errorCode)
+
+	return b.String()
+}
@@ -0,0 +1,175 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ohler55/ojg/jp"
+)
+
+// Diagnostic severities, configured via `result_validation.severity`.
+const (
+	severityError   = "error"
+	severityWarning = "warning"
+)
+
+// bodyExcerptLength is the maximum length in bytes of a response body
+// excerpt included in diagnostics.
+const bodyExcerptLength = 256
+
+type resultValidationModel struct {
+	StatusCodes  types.List   `tfsdk:"status_codes"`
+	Header       types.String `tfsdk:"header"`
+	JSONPath     types.String `tfsdk:"jsonpath"`
+	Equals       types.String `tfsdk:"equals"`
+	Regex        types.String `tfsdk:"regex"`
+	ErrorMessage types.String `tfsdk:"error_message"`
+	Severity     types.String `tfsdk:"severity"`
+}
+
+// validate returns diagnostics for an invalid condition.
+func (v resultValidationModel) validate(conditionPath path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if v.StatusCodes.IsNull() && v.Equals.IsNull() && v.Regex.IsNull() {
+		diags.AddAttributeError(
+			conditionPath,
+			"Missing Attribute Configuration",
+			"At least one of the status_codes, equals or regex attributes must be configured.",
+		)
+	}
+
+	if !v.JSONPath.IsNull() && !v.JSONPath.IsUnknown() {
+		if _, err := jp.ParseString(v.JSONPath.ValueString()); err != nil {
+			diags.AddAttributeError(
+				conditionPath.AtName("jsonpath"),
+				"Invalid JSONPath Expression",
+				fmt.Sprintf("The JSONPath expression %q could not be parsed: %s", v.JSONPath.ValueString(), err),
+			)
+		}
+	}
+
+	if !v.Regex.IsNull() && !v.Regex.IsUnknown() {
+		if _, err := regexp.Compile(v.Regex.ValueString()); err != nil {
+			diags.AddAttributeError(
+				conditionPath.AtName("regex"),
+				"Invalid Regular Expression",
+				fmt.Sprintf("The regular expression could not be compiled: %s", err),
+			)
+		}
+	}
+
+	return diags
+}
+
+// check evaluates the condition against the response and its body. It
+// returns a description of the offending value if the condition does not
+// hold, or an empty string if it does.
+func (v resultValidationModel) check(ctx context.Context, response *http.Response, body string) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if !v.StatusCodes.IsNull() {
+		var statusCodes []int64
+
+		diags.Append(v.StatusCodes.ElementsAs(ctx, &statusCodes, false)...)
+		if diags.HasError() {
+			return "", diags
+		}
+
+		if !slices.Contains(statusCodes, int64(response.StatusCode)) {
+			return fmt.Sprintf("The response status code is %d.", response.StatusCode), diags
+		}
+	}
+
+	if v.Equals.IsNull() && v.Regex.IsNull() {
+		return "", diags
+	}
+
+	var subject, value string
+
+	switch {
+	case !v.Header.IsNull():
+		name := v.Header.ValueString()
+
+		values := response.Header.Values(name)
+		if len(values) == 0 {
+			return fmt.Sprintf("The response header %q is not present.", name), diags
+		}
+
+		subject = fmt.Sprintf("response header %q", name)
+		value = strings.Join(values, ", ")
+	case !v.JSONPath.IsNull():
+		expression := v.JSONPath.ValueString()
+
+		document, err := parseJSONPathDocument([]byte(body))
+		if err != nil {
+			return fmt.Sprintf("The response body could not be parsed as JSON: %s", err), diags
+		}
+
+		result, ok, err := jsonPathString(document, expression)
+		if err != nil {
+			diags.AddError(
+				"Error evaluating JSONPath expression",
+				fmt.Sprintf("The JSONPath expression %q could not be evaluated: %s", expression, err),
+			)
+			return "", diags
+		}
+
+		if !ok {
+			return fmt.Sprintf("The JSONPath expression %q did not match.", expression), diags
+		}
+
+		subject = fmt.Sprintf("result of the JSONPath expression %q", expression)
+		value = result
+	default:
+		subject = "response body"
+		value = body
+	}
+
+	if !v.Equals.IsNull() && value != v.Equals.ValueString() {
+		return fmt.Sprintf("The %s is %q, not %q.", subject, bodyExcerpt(value), v.Equals.ValueString()), diags
+	}
+
+	if !v.Regex.IsNull() {
+		pattern, err := regexp.Compile(v.Regex.ValueString())
+		if err != nil {
+			diags.AddError(
+				"Invalid Regular Expression",
+				fmt.Sprintf("The regular expression could not be compiled: %s", err),
+			)
+			return "", diags
+		}
+
+		if !pattern.MatchString(value) {
+			return fmt.Sprintf("The %s %q does not match %q.", subject, bodyExcerpt(value), pattern), diags
+		}
+	}
+
+	return "", diags
+}
+
+// bodyExcerpt truncates the value to at most bodyExcerptLength bytes,
+// without splitting a UTF-8 encoded character.
+func bodyExcerpt(value string) string {
+	if len(value) <= bodyExcerptLength {
+		return value
+	}
+
+	end := bodyExcerptLength
+	for end > 0 && !utf8.RuneStart(value[end]) {
+		end--
+	}
+
+	return value[:end] + "..."
+}
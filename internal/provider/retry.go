@@ -0,0 +1,638 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/ohler55/ojg/jp"
+)
+
+// Backoff jitter strategies, configured via `retry.jitter`.
+const (
+	jitterNone  = "none"
+	jitterFull  = "full"
+	jitterEqual = "equal"
+)
+
+// retryBlock returns the `retry` block of the data sources which make
+// requests with retries, with the given description.
+func retryBlock(description string) schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Description: description,
+		Attributes: map[string]schema.Attribute{
+			"attempts": schema.Int64Attribute{
+				Description: "The number of times the request is to be retried. For example, if 2 is specified, the request will be tried a maximum of 3 times.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"min_delay_ms": schema.Int64Attribute{
+				Description: "The minimum delay between retry requests in milliseconds.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"max_delay_ms": schema.Int64Attribute{
+				Description: "The maximum delay between retry requests in milliseconds.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+					int64validator.AtLeastSumOf(path.MatchRelative().AtParent().AtName("min_delay_ms")),
+				},
+			},
+			"on": schema.ListAttribute{
+				Description: "The failures which are retried. `connection_errors` retries requests which fail without " +
+					"a response (e.g., the connection is refused or reset) and `status_codes` retries 429 and " +
+					"5xx-range (except 501) responses. For example, `[\"connection_errors\"]` never retries based on " +
+					"the status code. Defaults to both.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(stringvalidator.OneOf(retryOnConnectionErrors, retryOnStatusCodes)),
+				},
+			},
+			"retry_if": schema.StringAttribute{
+				Description: "An expression in Terraform syntax, such as " +
+					"`status_code == 200 && strcontains(response_body, \"PENDING\")`. If configured, a response which " +
+					"would otherwise not be retried is also retried while the expression is `true`. The variables " +
+					"`status_code`, `response_body` and `attempt`, which starts at 1, are available, as are the " +
+					"functions `can`, `contains`, `jsondecode`, `length`, `lookup`, `lower`, `regexall`, " +
+					"`strcontains`, `trimspace`, `try` and `upper`. An expression which cannot be evaluated or " +
+					"does not return a bool is treated as `false`. Note that `${` must be escaped as `$${` in the " +
+					"string. The read fails if the expression is still `true` after all attempts.",
+				Optional: true,
+			},
+			"retry_non_idempotent": schema.BoolAttribute{
+				Description: "Whether requests using a non-idempotent method, i.e. `POST`, are retried. Set this to " +
+					"`false` if the request has side effects, unless the endpoint deduplicates retried requests " +
+					"(e.g., with an idempotency key header). Defaults to `true`.",
+				Optional: true,
+			},
+			"jitter": schema.StringAttribute{
+				Description: "The jitter applied to the delay between retry requests, so that concurrent requests " +
+					"do not retry in lockstep. `none` uses the exponential backoff delay as is, `full` waits a random " +
+					"duration between zero and the delay and `equal` waits a random duration between half the delay " +
+					"and the delay. The delay specified by a `Retry-After` header is not randomized. Defaults to `none`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(jitterNone, jitterFull, jitterEqual),
+				},
+			},
+			"max_elapsed_time_ms": schema.Int64Attribute{
+				Description: "The maximum time in milliseconds spent on the request, including all retries, delays " +
+					"between them and reading the response body, regardless of the number of `attempts`.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"while_body_matches": schema.StringAttribute{
+				Description: "A regular expression ([RE2 syntax](https://github.com/google/re2/wiki/Syntax)). " +
+					"If configured, a response which would otherwise not be retried is also retried while its body " +
+					"matches, which allows an endpoint to be polled until, for example, a resource is no longer " +
+					"provisioning. The read fails if the body still matches after all attempts.",
+				Optional: true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"until": schema.SingleNestedBlock{
+				Description: "A condition on the JSON response body. If configured, a response which would otherwise " +
+					"not be retried is also retried until the result of the JSONPath expression equals `equals` or " +
+					"matches `regex`, which allows the data source to wait for an asynchronous operation to " +
+					"complete. The read fails if the condition does not hold after all attempts.",
+				Validators: []validator.Object{
+					objectvalidator.AlsoRequires(path.MatchRelative().AtName("jsonpath")),
+				},
+				Attributes: map[string]schema.Attribute{
+					"jsonpath": schema.StringAttribute{
+						Description: "The JSONPath expression evaluated against the response body. The result " +
+							"is formatted in the same way as `response_jsonpath`.",
+						Optional: true,
+					},
+					"equals": schema.StringAttribute{
+						Description: "The expected result of the JSONPath expression.",
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("regex")),
+						},
+					},
+					"regex": schema.StringAttribute{
+						Description: "A regular expression ([RE2 syntax](https://github.com/google/re2/wiki/Syntax)) " +
+							"which the result of the JSONPath expression must match.",
+						Optional: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// validateRetry validates the expressions of the `retry` block of a data
+// source.
+func validateRetry(ctx context.Context, retryObject types.Object) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if retryObject.IsNull() || retryObject.IsUnknown() {
+		return diags
+	}
+
+	var retry retryModel
+
+	diags.Append(retryObject.As(ctx, &retry, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return diags
+	}
+
+	if !retry.WhileBodyMatches.IsNull() && !retry.WhileBodyMatches.IsUnknown() {
+		if _, err := regexp.Compile(retry.WhileBodyMatches.ValueString()); err != nil {
+			diags.AddAttributeError(
+				path.Root("retry").AtName("while_body_matches"),
+				"Invalid Regular Expression",
+				fmt.Sprintf("The regular expression could not be compiled: %s", err),
+			)
+		}
+	}
+
+	if !retry.RetryIf.IsNull() && !retry.RetryIf.IsUnknown() {
+		if _, err := parseRetryIf(retry.RetryIf.ValueString()); err != nil {
+			diags.AddAttributeError(
+				path.Root("retry").AtName("retry_if"),
+				"Invalid Retry Expression",
+				fmt.Sprintf("The retry_if expression could not be parsed: %s", err),
+			)
+		}
+	}
+
+	if retry.Until.IsNull() || retry.Until.IsUnknown() {
+		return diags
+	}
+
+	var until retryUntilModel
+
+	diags.Append(retry.Until.As(ctx, &until, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return diags
+	}
+
+	untilPath := path.Root("retry").AtName("until")
+
+	if !until.JSONPath.IsNull() && !until.JSONPath.IsUnknown() {
+		if _, err := jp.ParseString(until.JSONPath.ValueString()); err != nil {
+			diags.AddAttributeError(
+				untilPath.AtName("jsonpath"),
+				"Invalid JSONPath Expression",
+				fmt.Sprintf("The JSONPath expression could not be parsed: %s", err),
+			)
+		}
+	}
+
+	if until.Equals.IsNull() && until.Regex.IsNull() {
+		diags.AddAttributeError(
+			untilPath,
+			"Missing Attribute Configuration",
+			"Either the equals or the regex attribute must be configured.",
+		)
+	}
+
+	if !until.Regex.IsNull() && !until.Regex.IsUnknown() {
+		if _, err := regexp.Compile(until.Regex.ValueString()); err != nil {
+			diags.AddAttributeError(
+				untilPath.AtName("regex"),
+				"Invalid Regular Expression",
+				fmt.Sprintf("The regular expression could not be compiled: %s", err),
+			)
+		}
+	}
+
+	return diags
+}
+
+// retryOptions are the settings of configureRetry which do not come from the
+// `retry` block.
+type retryOptions struct {
+	// method is the method of the requests, which are not retried if it is
+	// POST and `retry_non_idempotent` is false.
+	method string
+
+	// decodeContentEncoding is true if the response bodies checked by body
+	// retry conditions are decoded according to their Content-Encoding.
+	decodeContentEncoding bool
+
+	// retryBudget is the provider retry budget, if any.
+	retryBudget *retryBudget
+
+	// retryAfterHonored is set if a Retry-After header was honored.
+	retryAfterHonored *atomic.Bool
+
+	// retryWait accumulates the delays between attempts, if not nil.
+	retryWait *atomic.Int64
+}
+
+// configureRetry configures the retry policy and backoff of the client
+// according to the `retry` block of a data source. The `max_elapsed_time_ms`
+// deadline applies to the context of the request, so it is left to the
+// caller.
+func configureRetry(ctx context.Context, retryClient *retryablehttp.Client, retry retryModel, opts retryOptions) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	retryClient.RetryMax = int(retry.Attempts.ValueInt64())
+
+	if !retry.MinDelay.IsNull() && !retry.MinDelay.IsUnknown() && retry.MinDelay.ValueInt64() >= 0 {
+		retryClient.RetryWaitMin = time.Duration(retry.MinDelay.ValueInt64()) * time.Millisecond
+	}
+
+	if !retry.MaxDelay.IsNull() && !retry.MaxDelay.IsUnknown() && retry.MaxDelay.ValueInt64() >= 0 {
+		retryClient.RetryWaitMax = time.Duration(retry.MaxDelay.ValueInt64()) * time.Millisecond
+	}
+
+	if !retry.RetryNonIdempotent.IsNull() && !retry.RetryNonIdempotent.ValueBool() && opts.method == http.MethodPost {
+		retryClient.RetryMax = 0
+	}
+
+	var retryConditions []bodyRetryCondition
+
+	if !retry.WhileBodyMatches.IsNull() {
+		pattern, err := regexp.Compile(retry.WhileBodyMatches.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("retry").AtName("while_body_matches"),
+				"Invalid Regular Expression",
+				fmt.Sprintf("The regular expression could not be compiled: %s", err),
+			)
+			return diags
+		}
+
+		retryConditions = append(retryConditions, whileBodyMatches(pattern))
+	}
+
+	if !retry.Until.IsNull() {
+		var until retryUntilModel
+
+		diags.Append(retry.Until.As(ctx, &until, basetypes.ObjectAsOptions{})...)
+		if diags.HasError() {
+			return diags
+		}
+
+		x, err := jp.ParseString(until.JSONPath.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("retry").AtName("until").AtName("jsonpath"),
+				"Invalid JSONPath Expression",
+				fmt.Sprintf("The JSONPath expression could not be parsed: %s", err),
+			)
+			return diags
+		}
+
+		var pattern *regexp.Regexp
+
+		if !until.Regex.IsNull() {
+			pattern, err = regexp.Compile(until.Regex.ValueString())
+			if err != nil {
+				diags.AddAttributeError(
+					path.Root("retry").AtName("until").AtName("regex"),
+					"Invalid Regular Expression",
+					fmt.Sprintf("The regular expression could not be compiled: %s", err),
+				)
+				return diags
+			}
+		}
+
+		retryConditions = append(retryConditions, untilJSONPath(until.JSONPath.ValueString(), x, until.Equals.ValueStringPointer(), pattern))
+	}
+
+	if !retry.RetryIf.IsNull() {
+		expr, err := parseRetryIf(retry.RetryIf.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("retry").AtName("retry_if"),
+				"Invalid Retry Expression",
+				fmt.Sprintf("The retry_if expression could not be parsed: %s", err),
+			)
+			return diags
+		}
+
+		retryConditions = append(retryConditions, retryIf(retry.RetryIf.ValueString(), expr))
+	}
+
+	checkRetry := retryablehttp.DefaultRetryPolicy
+
+	if !retry.On.IsNull() {
+		var on []string
+
+		diags.Append(retry.On.ElementsAs(ctx, &on, false)...)
+		if diags.HasError() {
+			return diags
+		}
+
+		checkRetry = retryOnPolicy(on)
+	}
+
+	if len(retryConditions) > 0 {
+		checkRetry = bodyRetryPolicy(checkRetry, opts.decodeContentEncoding, retryConditions...)
+	}
+
+	if opts.retryBudget != nil {
+		checkRetry = budgetRetryPolicy(checkRetry, opts.retryBudget)
+	}
+
+	retryClient.CheckRetry = checkRetry
+
+	var backoff retryablehttp.Backoff = retryAfterBackoff(opts.retryAfterHonored, retry.Jitter.ValueString())
+
+	if opts.retryWait != nil {
+		backoff = countingBackoff(backoff, opts.retryWait)
+	}
+
+	retryClient.Backoff = backoff
+
+	return diags
+}
+
+// retryAfterBackoff returns a backoff policy which waits for the duration of
+// the Retry-After header of 429 and 503 responses, bounded by the maximum
+// delay, and otherwise uses exponential backoff with the given jitter. The
+// given flag is set if the Retry-After header was honored.
+func retryAfterBackoff(honored *atomic.Bool, jitter string) retryablehttp.Backoff {
+	return func(minDelay, maxDelay time.Duration, attemptNum int, resp *http.Response) time.Duration {
+		if resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
+			if delay, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+				honored.Store(true)
+
+				return min(delay, maxDelay)
+			}
+		}
+
+		// The response is not passed, so that the Retry-After header is not
+		// used without being bounded.
+		return applyJitter(retryablehttp.DefaultBackoff(minDelay, maxDelay, attemptNum, nil), jitter)
+	}
+}
+
+// countingBackoff returns a backoff policy which adds the delays of the given
+// policy to total, in nanoseconds.
+func countingBackoff(backoff retryablehttp.Backoff, total *atomic.Int64) retryablehttp.Backoff {
+	return func(minDelay, maxDelay time.Duration, attemptNum int, resp *http.Response) time.Duration {
+		delay := backoff(minDelay, maxDelay, attemptNum, resp)
+		total.Add(int64(delay))
+
+		return delay
+	}
+}
+
+// applyJitter randomizes the delay, so that concurrent clients do not retry
+// in lockstep. Full jitter waits between zero and the delay, while equal
+// jitter waits between half the delay and the delay.
+func applyJitter(delay time.Duration, jitter string) time.Duration {
+	if delay <= 0 {
+		return delay
+	}
+
+	switch jitter {
+	case jitterFull:
+		return time.Duration(rand.Int64N(int64(delay) + 1))
+	case jitterEqual:
+		half := delay / 2
+		return half + time.Duration(rand.Int64N(int64(delay-half)+1))
+	}
+
+	return delay
+}
+
+// parseRetryAfter parses a Retry-After header value, which is either a
+// number of seconds or an HTTP date, into a delay relative to now.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	return max(date.Sub(now), 0), true
+}
+
+// Retry triggers, configured via `retry.on`.
+const (
+	retryOnConnectionErrors = "connection_errors"
+	retryOnStatusCodes      = "status_codes"
+)
+
+// retryOnPolicy returns a retry policy which only retries the requests that
+// the default policy retries for one of the given triggers: a failed request
+// for connection_errors, or a 429 or 5xx-range (except 501) status code for
+// status_codes.
+func retryOnPolicy(on []string) retryablehttp.CheckRetry {
+	return func(ctx context.Context, resp *http.Response, err error) (bool, error) {
+		shouldRetry, checkErr := retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+		if !shouldRetry {
+			return false, checkErr
+		}
+
+		if err != nil {
+			return slices.Contains(on, retryOnConnectionErrors), checkErr
+		}
+
+		return slices.Contains(on, retryOnStatusCodes), checkErr
+	}
+}
+
+// errRetryBudgetExhausted is returned instead of retrying a request once the
+// provider `retry_budget` has been spent.
+var errRetryBudgetExhausted = errors.New("the provider retry budget has been spent")
+
+// retryBudget limits the total number of retries of all data sources, so
+// that an outage does not cause a large number of retries. A nil budget is
+// unlimited.
+type retryBudget struct {
+	size      int64
+	remaining atomic.Int64
+}
+
+func newRetryBudget(size int64) *retryBudget {
+	b := &retryBudget{
+		size: size,
+	}
+	b.remaining.Store(size)
+
+	return b
+}
+
+// take spends one retry of the budget, returning false if the budget has been
+// spent.
+func (b *retryBudget) take() bool {
+	if b == nil {
+		return true
+	}
+
+	return b.remaining.Add(-1) >= 0
+}
+
+// budgetRetryPolicy returns a retry policy which only retries the requests
+// that the base policy retries while the budget has not been spent. Once it
+// has, the request fails with an error wrapping errRetryBudgetExhausted and
+// the reason for the retry.
+func budgetRetryPolicy(base retryablehttp.CheckRetry, budget *retryBudget) retryablehttp.CheckRetry {
+	return func(ctx context.Context, resp *http.Response, err error) (bool, error) {
+		shouldRetry, checkErr := base(ctx, resp, err)
+		if !shouldRetry || budget.take() {
+			return shouldRetry, checkErr
+		}
+
+		reason := checkErr
+		if reason == nil {
+			reason = err
+		}
+
+		if reason == nil && resp != nil {
+			reason = fmt.Errorf("unexpected response status %q", resp.Status)
+		}
+
+		if reason == nil {
+			return false, errRetryBudgetExhausted
+		}
+
+		return false, fmt.Errorf("%w: %w", errRetryBudgetExhausted, reason)
+	}
+}
+
+// bodyRetryCondition returns an error describing why a response with the
+// given body should be retried, or nil if it should not be retried. The
+// attempt is the number of the request attempt, starting at 1.
+type bodyRetryCondition func(attempt int, resp *http.Response, body []byte) error
+
+// bodyRetryPolicy returns a retry policy which, in addition to the base
+// policy, retries responses whose body satisfies any of the conditions. The
+// body is decoded according to the Content-Encoding header if
+// decodeContentEncoding is true and is restored for the caller.
+func bodyRetryPolicy(base retryablehttp.CheckRetry, decodeContentEncoding bool, conditions ...bodyRetryCondition) retryablehttp.CheckRetry {
+	// The attempt is reset once a request is not retried, as the client is
+	// reused for subsequent requests, such as pages.
+	var attempt int
+
+	return func(ctx context.Context, resp *http.Response, err error) (bool, error) {
+		attempt++
+
+		shouldRetry, checkErr := base(ctx, resp, err)
+		if shouldRetry || checkErr != nil || resp == nil {
+			if !shouldRetry {
+				attempt = 0
+			}
+
+			return shouldRetry, checkErr
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			attempt = 0
+			return false, err
+		}
+
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+
+		var reader io.Reader = bytes.NewReader(body)
+
+		if decodeContentEncoding {
+			reader, err = contentDecodingReader(resp.Header, reader)
+			if err != nil {
+				attempt = 0
+				return false, err
+			}
+		}
+
+		decoded, err := io.ReadAll(reader)
+		if err != nil {
+			attempt = 0
+			return false, err
+		}
+
+		for _, condition := range conditions {
+			if err := condition(attempt, resp, decoded); err != nil {
+				return true, err
+			}
+		}
+
+		attempt = 0
+
+		return false, nil
+	}
+}
+
+// whileBodyMatches retries while the body matches the regular expression.
+func whileBodyMatches(pattern *regexp.Regexp) bodyRetryCondition {
+	return func(_ int, _ *http.Response, body []byte) error {
+		if pattern.Match(body) {
+			return fmt.Errorf("the response body still matches %q", pattern)
+		}
+
+		return nil
+	}
+}
+
+// untilJSONPath retries until the result of the JSONPath expression, as
+// returned by jsonPathResultString, equals the expected value or matches the
+// regular expression, whichever is not nil.
+func untilJSONPath(expression string, x jp.Expr, equals *string, pattern *regexp.Regexp) bodyRetryCondition {
+	return func(_ int, _ *http.Response, body []byte) error {
+		document, err := parseJSONPathDocument(body)
+		if err != nil {
+			return fmt.Errorf("the response body could not be parsed as JSON: %w", err)
+		}
+
+		result, ok, err := jsonPathResultString(x.Get(document))
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return fmt.Errorf("the JSONPath expression %q did not match", expression)
+		}
+
+		if equals != nil && result != *equals {
+			return fmt.Errorf("the JSONPath expression %q is %q, not %q", expression, result, *equals)
+		}
+
+		if pattern != nil && !pattern.MatchString(result) {
+			return fmt.Errorf("the JSONPath expression %q is %q, which does not match %q", expression, result, pattern)
+		}
+
+		return nil
+	}
+}
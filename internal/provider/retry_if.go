@@ -0,0 +1,100 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/tryfunc"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	"github.com/zclconf/go-cty/cty/function"
+	"github.com/zclconf/go-cty/cty/function/stdlib"
+)
+
+// Variables available in `retry.retry_if` expressions.
+const (
+	retryIfStatusCode   = "status_code"
+	retryIfResponseBody = "response_body"
+	retryIfAttempt      = "attempt"
+)
+
+// retryIfFunctions are the functions available in `retry.retry_if`
+// expressions, which behave like the Terraform functions of the same name.
+var retryIfFunctions = map[string]function.Function{
+	"can":         tryfunc.CanFunc,
+	"contains":    stdlib.ContainsFunc,
+	"jsondecode":  stdlib.JSONDecodeFunc,
+	"length":      stdlib.LengthFunc,
+	"lookup":      stdlib.LookupFunc,
+	"lower":       stdlib.LowerFunc,
+	"regexall":    stdlib.RegexAllFunc,
+	"strcontains": strContainsFunc,
+	"trimspace":   stdlib.TrimSpaceFunc,
+	"try":         tryfunc.TryFunc,
+	"upper":       stdlib.UpperFunc,
+}
+
+var strContainsFunc = function.New(&function.Spec{
+	Params: []function.Parameter{
+		{Name: "str", Type: cty.String},
+		{Name: "substr", Type: cty.String},
+	},
+	Type: function.StaticReturnType(cty.Bool),
+	Impl: func(args []cty.Value, _ cty.Type) (cty.Value, error) {
+		return cty.BoolVal(strings.Contains(args[0].AsString(), args[1].AsString())), nil
+	},
+})
+
+// parseRetryIf parses a `retry.retry_if` expression and checks that it only
+// refers to the available variables.
+func parseRetryIf(source string) (hcl.Expression, error) {
+	expr, diags := hclsyntax.ParseExpression([]byte(source), "retry_if", hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	for _, traversal := range expr.Variables() {
+		switch name := traversal.RootName(); name {
+		case retryIfStatusCode, retryIfResponseBody, retryIfAttempt:
+		default:
+			return nil, fmt.Errorf("unknown variable %q, the available variables are %s, %s and %s",
+				name, retryIfStatusCode, retryIfResponseBody, retryIfAttempt)
+		}
+	}
+
+	return expr, nil
+}
+
+// retryIf retries while the expression evaluates to true.
+func retryIf(source string, expr hcl.Expression) bodyRetryCondition {
+	return func(attempt int, resp *http.Response, body []byte) error {
+		evalCtx := &hcl.EvalContext{
+			Variables: map[string]cty.Value{
+				retryIfStatusCode:   cty.NumberIntVal(int64(resp.StatusCode)),
+				retryIfResponseBody: cty.StringVal(string(body)),
+				retryIfAttempt:      cty.NumberIntVal(int64(attempt)),
+			},
+			Functions: retryIfFunctions,
+		}
+
+		// An expression which cannot be evaluated, for example because the
+		// body is not JSON, or whose result is not a bool is treated as false.
+		value, diags := expr.Value(evalCtx)
+		if diags.HasErrors() {
+			return nil
+		}
+
+		value, err := convert.Convert(value, cty.Bool)
+		if err != nil || value.IsNull() || !value.IsKnown() || value.False() {
+			return nil
+		}
+
+		return fmt.Errorf("the retry_if expression %q is true", source)
+	}
+}
@@ -0,0 +1,275 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/crypto/ocsp"
+)
+
+// parseCRLs parses certificate revocation lists, configured via `crl_pem` or
+// downloaded from `crl_url`, which are either PEM encoded, possibly with
+// several CRLs, or a single DER encoded CRL.
+func parseCRLs(data []byte) ([]*x509.RevocationList, error) {
+	if block, _ := pem.Decode(data); block == nil {
+		crl, err := x509.ParseRevocationList(data)
+		if err != nil {
+			return nil, err
+		}
+
+		return []*x509.RevocationList{crl}, nil
+	}
+
+	var crls []*x509.RevocationList
+
+	for {
+		block, rest := pem.Decode(data)
+		if block == nil {
+			break
+		}
+
+		data = rest
+
+		if block.Type != "X509 CRL" {
+			return nil, fmt.Errorf("unexpected PEM block of type %q, expected X509 CRL", block.Type)
+		}
+
+		crl, err := x509.ParseRevocationList(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		crls = append(crls, crl)
+	}
+
+	return crls, nil
+}
+
+// crlsKey returns a string identifying the CRLs.
+func crlsKey(crls []*x509.RevocationList) string {
+	var key string
+
+	for _, crl := range crls {
+		hash := sha256.Sum256(crl.Raw)
+		key += hex.EncodeToString(hash[:])
+	}
+
+	return key
+}
+
+// verifyCRLs returns a function for tls.Config.VerifyConnection which fails
+// if a certificate of the chain presented by the server has been revoked
+// according to the CRLs. A CRL of the issuer of the server certificate is
+// required, while other certificates of the chain are only checked if a CRL
+// of their issuer is available.
+func verifyCRLs(crls []*x509.RevocationList) func(tls.ConnectionState) error {
+	return func(state tls.ConnectionState) error {
+		chain := state.PeerCertificates
+		if len(state.VerifiedChains) > 0 {
+			chain = state.VerifiedChains[0]
+		}
+
+		now := time.Now()
+
+		for i, cert := range chain {
+			if bytes.Equal(cert.RawIssuer, cert.RawSubject) {
+				continue
+			}
+
+			crl := issuerCRL(crls, cert)
+			if crl == nil {
+				if i == 0 {
+					return fmt.Errorf("no certificate revocation list (CRL) of the issuer %q of the server certificate is configured", cert.Issuer)
+				}
+
+				continue
+			}
+
+			if i+1 >= len(chain) {
+				return fmt.Errorf("the issuer %q of the certificate %q was not presented by the server, so the CRL cannot be verified", cert.Issuer, cert.Subject)
+			}
+
+			if err := crl.CheckSignatureFrom(chain[i+1]); err != nil {
+				return fmt.Errorf("the CRL of the issuer %q could not be verified: %w", cert.Issuer, err)
+			}
+
+			if !crl.NextUpdate.IsZero() && now.After(crl.NextUpdate) {
+				return fmt.Errorf("the CRL of the issuer %q expired at %s", cert.Issuer, crl.NextUpdate.Format(time.RFC3339))
+			}
+
+			for _, entry := range crl.RevokedCertificateEntries {
+				if entry.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+					return fmt.Errorf("the certificate %q with serial number %s was revoked at %s",
+						cert.Subject, cert.SerialNumber, entry.RevocationTime.Format(time.RFC3339))
+				}
+			}
+		}
+
+		return nil
+	}
+}
+
+// issuerCRL returns the CRL of the issuer of the certificate, if any.
+func issuerCRL(crls []*x509.RevocationList, cert *x509.Certificate) *x509.RevocationList {
+	for _, crl := range crls {
+		if bytes.Equal(crl.RawIssuer, cert.RawIssuer) {
+			return crl
+		}
+	}
+
+	return nil
+}
+
+// verifyConnection returns a function for tls.Config.VerifyConnection which
+// succeeds if all of the verifiers succeed, or nil if there are none.
+func verifyConnection(verifiers ...func(tls.ConnectionState) error) func(tls.ConnectionState) error {
+	if len(verifiers) == 0 {
+		return nil
+	}
+
+	return func(state tls.ConnectionState) error {
+		var errs []error
+
+		for _, verify := range verifiers {
+			if err := verify(state); err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		return errors.Join(errs...)
+	}
+}
+
+// ocspTimeout is the timeout of requests to OCSP responders.
+const ocspTimeout = 10 * time.Second
+
+// verifyOCSP returns a function for tls.Config.VerifyConnection which fails
+// if the server certificate has been revoked according to OCSP. The response
+// stapled by the server is used if present. Otherwise, the request fails if
+// requireStaple is true, or the OCSP responder of the certificate is queried
+// with the provider configuration if query is true.
+func verifyOCSP(p *providerData, requireStaple, query bool) func(tls.ConnectionState) error {
+	return func(state tls.ConnectionState) error {
+		chain := state.PeerCertificates
+		if len(state.VerifiedChains) > 0 {
+			chain = state.VerifiedChains[0]
+		}
+
+		if len(chain) < 2 {
+			return errors.New("the issuer of the server certificate was not presented by the server, so OCSP cannot be verified")
+		}
+
+		cert, issuer := chain[0], chain[1]
+		responseBytes := state.OCSPResponse
+
+		if len(responseBytes) == 0 {
+			if requireStaple {
+				return errors.New("the server did not staple an OCSP response to the TLS handshake")
+			}
+
+			if !query {
+				return nil
+			}
+
+			var err error
+
+			responseBytes, err = queryOCSP(p, cert, issuer)
+			if err != nil {
+				return fmt.Errorf("the OCSP responder of the certificate %q could not be queried: %w", cert.Subject, err)
+			}
+		}
+
+		response, err := ocsp.ParseResponseForCert(responseBytes, cert, issuer)
+		if err != nil {
+			return fmt.Errorf("the OCSP response for the certificate %q could not be verified: %w", cert.Subject, err)
+		}
+
+		if !response.NextUpdate.IsZero() && time.Now().After(response.NextUpdate) {
+			return fmt.Errorf("the OCSP response for the certificate %q expired at %s", cert.Subject, response.NextUpdate.Format(time.RFC3339))
+		}
+
+		switch response.Status {
+		case ocsp.Good:
+			return nil
+		case ocsp.Revoked:
+			return fmt.Errorf("the certificate %q with serial number %s was revoked at %s according to OCSP",
+				cert.Subject, cert.SerialNumber, response.RevokedAt.Format(time.RFC3339))
+		default:
+			return fmt.Errorf("the status of the certificate %q is unknown to the OCSP responder", cert.Subject)
+		}
+	}
+}
+
+// queryOCSP requests the OCSP response for the certificate from the first
+// OCSP responder listed in the certificate.
+func queryOCSP(p *providerData, cert, issuer *x509.Certificate) ([]byte, error) {
+	if len(cert.OCSPServer) == 0 {
+		return nil, errors.New("the certificate does not list an OCSP responder")
+	}
+
+	request, err := ocsp.CreateRequest(cert, issuer, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := ocspClient(p, cert.OCSPServer[0])
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := client.Post(cert.OCSPServer[0], "application/ocsp-request", bytes.NewReader(request))
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("the OCSP responder returned the status code %d", response.StatusCode)
+	}
+
+	return io.ReadAll(response.Body)
+}
+
+// ocspClient returns the client of requests to the OCSP responder at the URL,
+// which uses the provider configuration of its host, such as the proxy and
+// rate limit. The certificate verification settings of the data source apply
+// to the server rather than the responder, so they are not used. Neither is
+// the limit of concurrent requests, as the responder is queried during the
+// TLS handshake of a request which already holds one of the request slots.
+func ocspClient(p *providerData, responderURL string) (*http.Client, error) {
+	providerData := p.forURL(responderURL)
+
+	if providerData != nil {
+		data := *providerData
+		data.pinnedPublicKeys = nil
+		data.crls = nil
+		data.requireOCSPStaple = false
+		data.ocspCheck = false
+		data.requestSlots = nil
+		providerData = &data
+	}
+
+	tr, diags := newTransport(providerData, types.StringNull(), types.BoolNull())
+	if diags.HasError() {
+		return nil, errors.New(diags.Errors()[0].Detail())
+	}
+
+	return &http.Client{
+		Transport: roundTripper(providerData, tr),
+		Timeout:   ocspTimeout,
+	}, nil
+}
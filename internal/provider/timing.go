@@ -0,0 +1,134 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"crypto/tls"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// timingAttrTypes are the attribute types of the `timing` object.
+var timingAttrTypes = map[string]attr.Type{
+	"dns_ms":                types.Int64Type,
+	"connect_ms":            types.Int64Type,
+	"tls_ms":                types.Int64Type,
+	"time_to_first_byte_ms": types.Int64Type,
+	"total_ms":              types.Int64Type,
+}
+
+// requestTimer records the timing of the phases of a request using
+// httptrace. The timing is reset when a connection is requested, so that
+// only the final attempt is recorded.
+type requestTimer struct {
+	mu sync.Mutex
+
+	start        time.Time
+	dnsStart     time.Time
+	dnsDone      time.Time
+	connectStart time.Time
+	connectDone  time.Time
+	tlsStart     time.Time
+	tlsDone      time.Time
+	firstByte    time.Time
+}
+
+// clientTrace returns the httptrace hooks which record the timing.
+func (t *requestTimer) clientTrace() *httptrace.ClientTrace {
+	record := func(f func()) {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		f()
+	}
+
+	return &httptrace.ClientTrace{
+		GetConn: func(string) {
+			record(func() {
+				t.start = time.Now()
+				t.dnsStart, t.dnsDone = time.Time{}, time.Time{}
+				t.connectStart, t.connectDone = time.Time{}, time.Time{}
+				t.tlsStart, t.tlsDone = time.Time{}, time.Time{}
+				t.firstByte = time.Time{}
+			})
+		},
+		DNSStart: func(httptrace.DNSStartInfo) {
+			record(func() { t.dnsStart = time.Now() })
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			record(func() { t.dnsDone = time.Now() })
+		},
+		ConnectStart: func(string, string) {
+			// Multiple connections may be attempted, e.g. for IPv4 and IPv6.
+			record(func() {
+				if t.connectStart.IsZero() {
+					t.connectStart = time.Now()
+				}
+			})
+		},
+		ConnectDone: func(string, string, error) {
+			record(func() { t.connectDone = time.Now() })
+		},
+		TLSHandshakeStart: func() {
+			record(func() { t.tlsStart = time.Now() })
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			record(func() { t.tlsDone = time.Now() })
+		},
+		GotFirstResponseByte: func() {
+			record(func() { t.firstByte = time.Now() })
+		},
+	}
+}
+
+// requestTimings are the durations of the phases of a request. Phases which
+// did not occur, e.g. because an existing connection was reused, are zero.
+type requestTimings struct {
+	started         time.Time
+	dns             time.Duration
+	connect         time.Duration
+	tls             time.Duration
+	timeToFirstByte time.Duration
+	total           time.Duration
+}
+
+// timings returns the durations of the phases of the request, using the
+// given time as the end of the request.
+func (t *requestTimer) timings(end time.Time) requestTimings {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	duration := func(start, end time.Time) time.Duration {
+		if start.IsZero() || end.IsZero() {
+			return 0
+		}
+
+		return end.Sub(start)
+	}
+
+	return requestTimings{
+		started:         t.start,
+		dns:             duration(t.dnsStart, t.dnsDone),
+		connect:         duration(t.connectStart, t.connectDone),
+		tls:             duration(t.tlsStart, t.tlsDone),
+		timeToFirstByte: duration(t.start, t.firstByte),
+		total:           duration(t.start, end),
+	}
+}
+
+// value returns the `timing` object.
+func (t requestTimings) value() (types.Object, diag.Diagnostics) {
+	return types.ObjectValue(timingAttrTypes, map[string]attr.Value{
+		"dns_ms":                types.Int64Value(t.dns.Milliseconds()),
+		"connect_ms":            types.Int64Value(t.connect.Milliseconds()),
+		"tls_ms":                types.Int64Value(t.tls.Milliseconds()),
+		"time_to_first_byte_ms": types.Int64Value(t.timeToFirstByte.Milliseconds()),
+		"total_ms":              types.Int64Value(t.total.Milliseconds()),
+	})
+}
@@ -0,0 +1,136 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// cipherSuites are the cipher suites implemented by crypto/tls by name,
+// including those with security issues, which may be needed to connect to
+// legacy devices.
+var cipherSuites = func() map[string]uint16 {
+	suites := make(map[string]uint16)
+
+	for _, suite := range append(tls.CipherSuites(), tls.InsecureCipherSuites()...) {
+		suites[suite.Name] = suite.ID
+	}
+
+	return suites
+}()
+
+// cipherSuiteNames returns the names of the supported cipher suites.
+func cipherSuiteNames() []string {
+	names := make([]string, 0, len(cipherSuites))
+
+	for _, suite := range append(tls.CipherSuites(), tls.InsecureCipherSuites()...) {
+		names = append(names, suite.Name)
+	}
+
+	return names
+}
+
+// cipherSuiteIDs returns the IDs of the cipher suites of a `tls_cipher_suites`
+// list, or nil if the list is null.
+func cipherSuiteIDs(ctx context.Context, list types.List) ([]uint16, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if list.IsNull() || list.IsUnknown() {
+		return nil, diags
+	}
+
+	var names []string
+
+	diags.Append(list.ElementsAs(ctx, &names, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	ids := make([]uint16, 0, len(names))
+
+	for _, name := range names {
+		id, ok := cipherSuites[name]
+		if !ok {
+			diags.AddError(
+				"Unsupported Cipher Suite",
+				fmt.Sprintf("The cipher suite %q is not supported.", name),
+			)
+			return nil, diags
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, diags
+}
+
+// tlsRenegotiation are the `tls_renegotiation` settings by value.
+var tlsRenegotiation = map[string]tls.RenegotiationSupport{
+	"never":  tls.RenegotiateNever,
+	"once":   tls.RenegotiateOnceAsClient,
+	"freely": tls.RenegotiateFreelyAsClient,
+}
+
+// pinnedPublicKeyPrefix is the prefix of `pinned_public_keys` entries, which
+// are base64 encoded SHA-256 hashes of a Subject Public Key Info (SPKI).
+const pinnedPublicKeyPrefix = "sha256/"
+
+// publicKeyPin returns the `pinned_public_keys` entry of the certificate.
+func publicKeyPin(cert *x509.Certificate) string {
+	hash := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+
+	return pinnedPublicKeyPrefix + base64.StdEncoding.EncodeToString(hash[:])
+}
+
+// verifyPinnedPublicKeys returns a function for tls.Config.VerifyConnection
+// which succeeds if the server certificate has one of the pinned public keys,
+// or if it is issued by a presented certificate with one of them. Only the
+// server certificate proves possession of its private key during the
+// handshake, so the other presented certificates are only trusted through a
+// verified chain from it.
+func verifyPinnedPublicKeys(pins []string) func(tls.ConnectionState) error {
+	return func(state tls.ConnectionState) error {
+		if len(state.PeerCertificates) == 0 {
+			return errors.New("the server did not present a certificate")
+		}
+
+		leaf := state.PeerCertificates[0]
+
+		if slices.Contains(pins, publicKeyPin(leaf)) {
+			return nil
+		}
+
+		roots := x509.NewCertPool()
+		intermediates := x509.NewCertPool()
+
+		for _, cert := range state.PeerCertificates[1:] {
+			if slices.Contains(pins, publicKeyPin(cert)) {
+				roots.AddCert(cert)
+			} else {
+				intermediates.AddCert(cert)
+			}
+		}
+
+		_, err := leaf.Verify(x509.VerifyOptions{
+			DNSName:       state.ServerName,
+			Roots:         roots,
+			Intermediates: intermediates,
+		})
+		if err != nil {
+			return fmt.Errorf("the server certificate does not have a pinned public key and is not issued by a certificate with one: %w", err)
+		}
+
+		return nil
+	}
+}
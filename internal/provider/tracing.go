@@ -0,0 +1,171 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// tracerName is the instrumentation scope of the spans of the provider.
+const tracerName = "github.com/terraform-providers/terraform-provider-http"
+
+// tracingDefaultServiceName is the default `tracing.service_name`.
+const tracingDefaultServiceName = "terraform-provider-http"
+
+type providerTracingModel struct {
+	Endpoint    types.String `tfsdk:"endpoint"`
+	Headers     types.Map    `tfsdk:"headers"`
+	ServiceName types.String `tfsdk:"service_name"`
+}
+
+// newTracerProvider returns a tracer provider which exports spans with
+// OTLP over HTTP, as configured by the provider `tracing` block.
+func newTracerProvider(ctx context.Context, model providerTracingModel) (*sdktrace.TracerProvider, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	options := []otlptracehttp.Option{
+		otlptracehttp.WithEndpointURL(model.Endpoint.ValueString()),
+	}
+
+	if !model.Headers.IsNull() {
+		var headers map[string]string
+
+		diags.Append(model.Headers.ElementsAs(ctx, &headers, false)...)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		options = append(options, otlptracehttp.WithHeaders(headers))
+	}
+
+	exporter, err := otlptracehttp.New(ctx, options...)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("tracing").AtName("endpoint"),
+			"Error Configuring Tracing",
+			"The OTLP trace exporter could not be created: "+err.Error(),
+		)
+		return nil, diags
+	}
+
+	serviceName := tracingDefaultServiceName
+	if !model.ServiceName.IsNull() {
+		serviceName = model.ServiceName.ValueString()
+	}
+
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(sdkresource.NewSchemaless(semconv.ServiceName(serviceName))),
+	), diags
+}
+
+// tracer returns the tracer of the provider, which does not record spans
+// unless tracing is configured.
+func (p *providerData) tracer() trace.Tracer {
+	if p == nil || p.tracerProvider == nil {
+		return noop.NewTracerProvider().Tracer(tracerName)
+	}
+
+	return p.tracerProvider.Tracer(tracerName)
+}
+
+// flushSpans exports the spans which have ended, as the provider process may
+// exit without the tracer provider being shut down.
+func (p *providerData) flushSpans(ctx context.Context) {
+	if p == nil || p.tracerProvider == nil {
+		return
+	}
+
+	if err := p.tracerProvider.ForceFlush(ctx); err != nil {
+		tflog.Warn(ctx, "Error exporting spans", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+}
+
+// endSpan ends the span, with an error status if there are errors.
+func endSpan(span trace.Span, diags diag.Diagnostics) {
+	if diags.HasError() {
+		span.SetStatus(codes.Error, diags.Errors()[0].Summary())
+	}
+
+	span.End()
+}
+
+// redactedURL returns the URL with any password replaced, as spans are sent
+// to a collector.
+func redactedURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+
+	return u.Redacted()
+}
+
+// timingAttributes returns the span attributes of the request timings.
+func timingAttributes(timings requestTimings) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.Int64("http.timing.dns_ms", timings.dns.Milliseconds()),
+		attribute.Int64("http.timing.connect_ms", timings.connect.Milliseconds()),
+		attribute.Int64("http.timing.tls_ms", timings.tls.Milliseconds()),
+		attribute.Int64("http.timing.time_to_first_byte_ms", timings.timeToFirstByte.Milliseconds()),
+		attribute.Int64("http.timing.total_ms", timings.total.Milliseconds()),
+	}
+}
+
+// tracingTransport records a client span for each request attempt and
+// propagates the trace context to the server.
+type tracingTransport struct {
+	base   http.RoundTripper
+	tracer trace.Tracer
+}
+
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := t.tracer.Start(req.Context(), "HTTP "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(req.Method),
+			semconv.URLFull(req.URL.Redacted()),
+			semconv.ServerAddress(req.URL.Hostname()),
+		),
+	)
+	defer span.End()
+
+	// The request must not be modified, so the trace context is set on a
+	// copy.
+	req = req.Clone(ctx)
+	propagation.TraceContext{}.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+
+	return resp, nil
+}
@@ -19,7 +19,9 @@ func main() {
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
-	err := providerserver.Serve(context.Background(), provider.New, providerserver.ServeOpts{
+	metrics := provider.NewMetrics()
+
+	err := providerserver.Serve(context.Background(), provider.NewWithMetrics(metrics), providerserver.ServeOpts{
 		Address:         "registry.terraform.io/hashicorp/http",
 		Debug:           debug,
 		ProtocolVersion: 5,
@@ -27,4 +29,11 @@ func main() {
 	if err != nil {
 		log.Fatal(err)
 	}
+
+	// The summary of the requests is logged if the provider server shuts
+	// down gracefully. The JSON report has been kept up to date already, as
+	// Terraform may stop the provider without waiting for it.
+	if err := metrics.Report(); err != nil {
+		log.Printf("[WARN] %s", err)
+	}
 }